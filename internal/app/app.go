@@ -8,13 +8,17 @@ import (
 	"Chronos/internal/config"
 	"Chronos/internal/handler"
 	"Chronos/internal/logger"
+	"Chronos/internal/models"
 	"Chronos/internal/notifier"
+	"Chronos/internal/notifier/channel"
 	"Chronos/internal/repository"
 	"Chronos/internal/server"
 	"Chronos/internal/service"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 
@@ -27,14 +31,15 @@ import (
 // It holds long-lived resources (logger, DB, cache, broker, server) and
 // the context/cancel function used for graceful shutdown.
 type App struct {
-	logger  logger.Logger      // logger is the structured logger used across application layers.
-	logFile *os.File           // logFile is the file handle where logs are written.
-	broker  broker.Broker      // broker is the message broker used for consuming/producing domain messages.
-	server  server.Server      // server is the HTTP server instance.
-	ctx     context.Context    // ctx is the root context used to coordinate shutdown across components.
-	cancel  context.CancelFunc // cancel cancels the root context when a shutdown signal is received.
-	cache   cache.Cache        // cache is the cache layer used by services (e.g., redis).
-	storage repository.Storage // storage is the data storage abstraction backed by the database.
+	logger   logger.Logger      // logger is the structured logger used across application layers.
+	logFile  *os.File           // logFile is the file handle where logs are written.
+	broker   broker.Broker      // broker is the message broker used for consuming/producing domain messages.
+	notifier notifier.Notifier  // notifier delivers notifications and holds channel connections.
+	server   server.Server      // server is the HTTP server instance.
+	ctx      context.Context    // ctx is the root context used to coordinate shutdown across components.
+	cancel   context.CancelFunc // cancel cancels the root context when a shutdown signal is received.
+	cache    cache.Cache        // cache is the cache layer used by services (e.g., redis).
+	storage  repository.Storage // storage is the data storage abstraction backed by the database.
 }
 
 // Boot loads configuration, initializes logger, connects to database and cache,
@@ -60,7 +65,7 @@ func Boot() *App {
 
 	app, err := wireApp(db, cache, logger, logFile, config)
 	if err != nil {
-		logger.LogFatal("app — failed to connect to broker", err, "layer", "app")
+		logger.LogFatal("app — failed to wire application", err, "layer", "app")
 	}
 
 	return app
@@ -93,31 +98,67 @@ func connectCache(logger logger.Logger, config config.Cache) (cache.Cache, error
 // handler, server), creates a cancellable context and returns the assembled *App.
 func wireApp(db *dbpg.DB, cache cache.Cache, logger logger.Logger, logFile *os.File, config config.Config) (*App, error) {
 
-	ctx, cancel := newContext(logger)
+	notifier, err := newNotifier(logger, config.Notifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register notification channels: %w", err)
+	}
+
 	storge := repository.NewStorage(logger, config.Storage, db)
-	notifier := notifier.NewNotifier(config.Notifier)
-	broker, err := broker.NewBroker(logger, config.Broker, cache, storge, notifier)
-	service := service.NewService(logger, broker, cache, storge)
-	handler := handler.NewHandler(service)
-	server := server.NewServer(logger, config.Server, handler)
 
+	broker, err := broker.NewBroker(logger, config.Broker, cache, storge, notifier)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to connect to broker: %w", err)
 	}
 
+	ctx, cancel := newContext(logger)
+	service := service.NewService(logger, broker, cache, storge, notifier)
+	handler := handler.NewHandler(service)
+	server := server.NewServer(logger, config.Server, handler)
+
 	return &App{
-		logger:  logger,
-		logFile: logFile,
-		broker:  broker,
-		server:  server,
-		ctx:     ctx,
-		cancel:  cancel,
-		cache:   cache,
-		storage: storge,
+		logger:   logger,
+		logFile:  logFile,
+		broker:   broker,
+		notifier: notifier,
+		server:   server,
+		ctx:      ctx,
+		cancel:   cancel,
+		cache:    cache,
+		storage:  storge,
 	}, nil
 
 }
 
+// newNotifier creates the channel registry and registers every channel enabled in config.
+// If no channels are configured, all built-in channels are registered, except the webhook channel
+// when no webhook secret is set, since its payloads must be signed. A webhook channel enabled
+// explicitly without a secret fails startup.
+func newNotifier(logger logger.Logger, config config.Notifier) (notifier.Notifier, error) {
+
+	names := config.Channels
+	if len(names) == 0 {
+		names = []string{models.Email, models.Telegram, models.Webhook, models.Stdout}
+		if config.WebhookSecret == "" {
+			names = slices.DeleteFunc(names, func(name string) bool { return name == models.Webhook })
+			logger.LogInfo("app — webhook channel not registered, WEBHOOK_SECRET is not set", "layer", "app")
+		}
+	}
+
+	channels := make([]notifier.Channel, 0, len(names))
+
+	for _, name := range names {
+		channel, err := channel.New(name, config)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+		logger.Debug("app — notification channel registered", "channel", channel.Name(), "layer", "app")
+	}
+
+	return notifier.NewNotifier(config, channels...)
+
+}
+
 // newContext creates a context that is cancelled when the process
 // receives SIGINT or SIGTERM. It also logs receipt of the signal
 // and initiates graceful shutdown by calling the cancel function.
@@ -166,8 +207,8 @@ func (a *App) Run() {
 }
 
 // Stop performs an orderly shutdown of application components: it shuts down
-// the server and broker, waits for background work to finish, closes cache and
-// storage, and closes the log file if it is not os.Stdout.
+// the server and broker, waits for background work to finish, closes notification
+// channels, cache and storage, and closes the log file if it is not os.Stdout.
 func (a *App) Stop(wg *sync.WaitGroup) {
 
 	a.server.Shutdown()
@@ -175,6 +216,7 @@ func (a *App) Stop(wg *sync.WaitGroup) {
 
 	wg.Wait()
 
+	a.notifier.Close()
 	a.cache.Close()
 	a.storage.Close()
 
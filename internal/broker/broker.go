@@ -3,6 +3,8 @@
 package broker
 
 import (
+	"Chronos/internal/broker/memory"
+	"Chronos/internal/broker/postgres"
 	rabbitmq "Chronos/internal/broker/rabbitMQ"
 	"Chronos/internal/cache"
 	"Chronos/internal/config"
@@ -10,18 +12,37 @@ import (
 	"Chronos/internal/models"
 	"Chronos/internal/notifier"
 	"Chronos/internal/repository"
+	"fmt"
+)
+
+const (
+	BackendRabbitMQ = "rabbitmq" // delayed delivery through per-notification RabbitMQ queues
+	BackendPostgres = "postgres" // delivery straight from the Notifications table, without RabbitMQ
+	BackendMemory   = "memory"   // in-process delivery for development and tests, without RabbitMQ
 )
 
 // Broker defines the interface for a message broker used by the application.
 // It supports consuming messages, producing notifications, and graceful shutdown.
 type Broker interface {
-	Consume() error                                 // Consume starts processing messages from the broker.
-	Produce(notification models.Notification) error // Produce sends a notification message to the broker.
-	Shutdown()                                      // Shutdown gracefully stops the broker and releases resources.
+	Consume() error                                    // Consume starts processing messages from the broker.
+	Produce(notification models.Notification) error    // Produce sends a notification message to the broker.
+	Reschedule(notification models.Notification) error // Reschedule replaces the queued message of a rescheduled notification.
+	Shutdown()                                         // Shutdown gracefully stops the broker and releases resources.
 }
 
-// NewBroker creates a new Broker instance. Currently, it returns a RabbitMQ-based broker.
+// NewBroker creates a new Broker instance of the backend selected in config, RabbitMQ by default.
 // It initializes the broker with the provided logger, configuration, cache, storage, and notifier.
 func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) (Broker, error) {
-	return rabbitmq.NewBroker(logger, config, cache, storage, notifier)
+
+	switch config.Backend {
+	case "", BackendRabbitMQ:
+		return rabbitmq.NewBroker(logger, config, cache, storage, notifier)
+	case BackendPostgres:
+		return postgres.NewBroker(logger, config, cache, storage, notifier)
+	case BackendMemory:
+		return memory.NewBroker(logger, config, cache, storage, notifier)
+	default:
+		return nil, fmt.Errorf("unknown broker backend %q", config.Backend)
+	}
+
 }
@@ -0,0 +1,23 @@
+package delivery
+
+import (
+	"Chronos/internal/models"
+	"time"
+)
+
+const defaultClaimTimeout = 5 * time.Minute
+
+// Claimable reports whether a notification in the given status may still be claimed for sending.
+// Notifications that are already being sent are included, since their claim may be taken over
+// once it is older than the claim timeout.
+func Claimable(status string) bool {
+	return status == models.StatusPending || status == models.StatusLate || status == models.StatusSending
+}
+
+// ClaimTimeout returns how long a claimed notification stays reserved for the consumer sending it.
+func (p *Processor) ClaimTimeout() time.Duration {
+	if p.config.Consumer.ClaimTimeout <= 0 {
+		return defaultClaimTimeout
+	}
+	return p.config.Consumer.ClaimTimeout
+}
@@ -0,0 +1,170 @@
+// Package delivery sends notifications handed over by a broker backend and records the outcome.
+// It holds the delivery logic shared by every backend: claiming, expiry, rendering, sending,
+// retries, dead-lettering and advancing recurring schedules. Backends differ only in how they
+// find due notifications and in how they schedule retries and collect dead letters.
+package delivery
+
+import (
+	"Chronos/internal/cache"
+	"Chronos/internal/config"
+	"Chronos/internal/logger"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/repository"
+	"Chronos/internal/templates"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Backend is implemented by broker backends to carry out what the processor decides.
+type Backend interface {
+	Retry(notification models.Notification, delay time.Duration) error         // Retry schedules another delivery attempt of the notification after delay.
+	DeadLetter(body []byte, notificationID string, attempts int, reason error) // DeadLetter collects a notification that could not be delivered.
+}
+
+// Processor delivers claimed notifications on behalf of a broker backend.
+type Processor struct {
+	logger   logger.Logger      // structured logger for logging delivery events
+	config   config.Broker      // broker configuration
+	cache    cache.Cache        // cache interface for temporary storage
+	storage  repository.Storage // storage interface for persistent storage
+	notifier notifier.Notifier  // notifier for sending notifications
+	backend  Backend            // backend that schedules retries and collects dead letters
+}
+
+// NewProcessor creates a Processor that delivers notifications for the given backend.
+func NewProcessor(logger logger.Logger, config config.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier, backend Backend) *Processor {
+	return &Processor{logger: logger, config: config, cache: cache, storage: storage, notifier: notifier, backend: backend}
+}
+
+// Deliver sends a notification the caller has claimed and records the outcome; status is the
+// status the notification had before it was claimed. It skips the notification and marks it as
+// expired if its delivery deadline has passed, loads the delivery state of its recipients, renders
+// its template if it has one, attempts to send it via the notifier to the recipients that have not
+// received it yet, and records the outcome for every recipient. A failed delivery is retried with
+// exponential backoff until the configured attempts are used up; only then is the outcome recorded
+// for the notification as a whole and the notification dead-lettered. Occurrences of recurring
+// schedules produce the next occurrence once they have been processed.
+// A nil error means the outcome has been written to storage. Otherwise the notification stays
+// claimed, and the claim is taken over once it is older than the claim timeout.
+func (p *Processor) Deliver(ctx context.Context, notification models.Notification, status string) error {
+
+	if status == models.StatusSending {
+		p.logger.Debug("consumer — taking over notification from a consumer that did not finish sending it",
+			"notificationID", notification.ID, "layer", "broker.delivery")
+		status = models.StatusPending
+	}
+
+	p.cacheStatus(ctx, notification.ID, models.StatusSending)
+
+	if p.expired(notification) {
+		p.logger.Debug("consumer — delivery deadline has passed, skipping notification",
+			"notificationID", notification.ID, "layer", "broker.delivery")
+		if err := p.updateStatus(ctx, notification.ID, models.StatusExpired); err != nil {
+			return err
+		}
+		p.Advance(ctx, notification)
+		return nil
+	}
+
+	var err error
+
+	notification.Recipients, err = p.storage.GetRecipients(ctx, notification.ID)
+	if err != nil {
+		return err
+	}
+
+	notification.Attempts++
+
+	sendErr := p.render(ctx, &notification)
+	if sendErr == nil {
+		sendErr = p.notifier.Notify(ctx, notification)
+	}
+	if sendErr != nil && ctx.Err() != nil {
+		p.logger.LogError("consumer — notification send interrupted by shutdown, leaving it for recovery",
+			sendErr, "notificationID", notification.ID, "layer", "broker.delivery")
+		return ctx.Err()
+	}
+
+	delivered := p.updateRecipients(ctx, notification, sendErr)
+	p.setAttempts(ctx, notification)
+
+	if sendErr != nil {
+
+		p.logger.LogError("consumer — failed to deliver notification", sendErr,
+			"notificationID", notification.ID, "attempt", notification.Attempts, "layer", "broker.delivery")
+
+		if p.retry(notification) {
+			return p.setStatus(ctx, notification.ID, status)
+		}
+
+		if body, err := json.Marshal(notification); err == nil {
+			p.backend.DeadLetter(body, notification.ID, notification.Attempts, sendErr)
+		}
+
+		outcome := models.StatusFailed
+		if delivered {
+			outcome = models.StatusPartiallySent
+		}
+		if err := p.updateStatus(ctx, notification.ID, outcome); err != nil {
+			return err
+		}
+
+		p.Advance(ctx, notification)
+
+		return nil
+
+	}
+
+	if err := p.updateStatus(ctx, notification.ID, status); err != nil {
+		return err
+	}
+
+	p.Advance(ctx, notification)
+
+	return nil
+
+}
+
+// expired reports whether the delivery deadline of the notification has passed.
+// Notifications without their own deadline expire the configured max delay after
+// their send time, or never if no max delay is configured.
+func (p *Processor) expired(notification models.Notification) bool {
+
+	deadline := notification.ExpiresAt
+
+	if deadline == nil {
+		if p.config.Consumer.MaxDelay <= 0 {
+			return false
+		}
+		d := notification.SendAt.Add(p.config.Consumer.MaxDelay)
+		deadline = &d
+	}
+
+	return time.Now().After(*deadline)
+
+}
+
+// render fills in the message of a templated notification from its template and params.
+// Templates are read at send time, so notifications pick up edits made after they were scheduled.
+// A rendering failure is recorded like a failed delivery.
+func (p *Processor) render(ctx context.Context, notification *models.Notification) error {
+
+	if notification.Template == "" {
+		return nil
+	}
+
+	tmpl, err := p.storage.GetTemplate(ctx, notification.Template)
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", notification.Template, err)
+	}
+
+	if err := templates.Render(tmpl, notification); err != nil {
+		return fmt.Errorf("failed to render template %s: %w", notification.Template, err)
+	}
+
+	return nil
+
+}
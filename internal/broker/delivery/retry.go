@@ -0,0 +1,50 @@
+package delivery
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/models"
+	"time"
+)
+
+// retry schedules another delivery attempt of a notification that failed to be delivered,
+// after the backoff delay for the attempts made so far. It reports false if no attempts are left.
+// A failure to schedule the retry is only logged: the notification goes back to its previous
+// status in storage and is re-queued by recovery.
+func (p *Processor) retry(notification models.Notification) bool {
+
+	if notification.Attempts >= p.config.Retry.Attempts {
+		return false
+	}
+
+	delay := RetryDelay(p.config.Retry, notification.Attempts)
+
+	if err := p.backend.Retry(notification, delay); err != nil {
+		p.logger.LogError("consumer — failed to schedule delivery retry, leaving it for recovery", err,
+			"notificationID", notification.ID, "attempt", notification.Attempts, "layer", "broker.delivery")
+		return true
+	}
+
+	p.logger.Debug("consumer — delivery retry scheduled", "notificationID", notification.ID,
+		"attempt", notification.Attempts, "delay", delay, "layer", "broker.delivery")
+
+	return true
+
+}
+
+// RetryDelay returns the backoff delay before the next attempt after the given number of
+// failed attempts: the configured delay, multiplied by the backoff for every further attempt
+// and capped at the maximum delay if one is configured.
+func RetryDelay(strategy config.Retry, attempts int) time.Duration {
+
+	delay := float64(strategy.Delay)
+
+	for range attempts - 1 {
+		delay *= strategy.Backoff
+		if strategy.MaxDelay > 0 && delay >= float64(strategy.MaxDelay) {
+			return strategy.MaxDelay
+		}
+	}
+
+	return time.Duration(delay)
+
+}
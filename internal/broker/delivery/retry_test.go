@@ -0,0 +1,31 @@
+package delivery
+
+import (
+	"Chronos/internal/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		strategy config.Retry
+		attempts int
+		expected time.Duration
+	}{
+		{"first retry", config.Retry{Delay: 30 * time.Second, Backoff: 2}, 1, 30 * time.Second},
+		{"backoff per attempt", config.Retry{Delay: 30 * time.Second, Backoff: 2}, 3, 2 * time.Minute},
+		{"capped at max delay", config.Retry{Delay: 30 * time.Second, Backoff: 2, MaxDelay: time.Minute}, 4, time.Minute},
+		{"below max delay", config.Retry{Delay: 30 * time.Second, Backoff: 2, MaxDelay: time.Hour}, 2, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RetryDelay(tt.strategy, tt.attempts))
+		})
+	}
+
+}
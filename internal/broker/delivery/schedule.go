@@ -0,0 +1,97 @@
+package delivery
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/recurrence"
+	"context"
+	"errors"
+	"time"
+)
+
+// Advance produces the next occurrence of the recurring schedule the notification belongs to.
+// It is called once the occurrence has been processed, whatever the outcome: a failed or
+// individually canceled occurrence does not stop the series, only canceling the schedule does.
+func (p *Processor) Advance(ctx context.Context, notification models.Notification) {
+
+	if notification.ScheduleID == "" {
+		return
+	}
+
+	schedule, err := p.storage.GetSchedule(ctx, notification.ScheduleID)
+	if err != nil {
+		p.logger.LogError("consumer — failed to get schedule", err,
+			"scheduleID", notification.ScheduleID, "layer", "broker.delivery")
+		return
+	}
+
+	if schedule.CurrentID != notification.ID {
+		return // a redelivery of an occurrence the schedule has already moved past
+	}
+
+	p.advanceSchedule(ctx, schedule)
+
+}
+
+// advanceSchedule computes the next occurrence of an active schedule and stores it in place of the
+// current one; the outbox relay produces it. Occurrences missed while the service was down are skipped rather
+// than sent in a burst. If the series has ended, the schedule is marked as finished.
+// Concurrent attempts to advance the same schedule are resolved by the storage.
+func (p *Processor) advanceSchedule(ctx context.Context, schedule models.Schedule) {
+
+	if schedule.Status != models.ScheduleActive {
+		return
+	}
+
+	after := time.Now()
+	if schedule.NextAt.After(after) {
+		after = schedule.NextAt
+	}
+
+	sendAt, ok, err := recurrence.Next(schedule, after)
+	if err != nil {
+		p.logger.LogError("consumer — failed to compute next schedule occurrence", err,
+			"scheduleID", schedule.ID, "layer", "broker.delivery")
+		return
+	}
+
+	var next *models.Notification
+
+	if ok {
+		occurrence := recurrence.Occurrence(schedule, sendAt)
+		notifier.Prepare(p.notifier, &occurrence)
+		next = &occurrence
+	}
+
+	err = p.storage.AdvanceSchedule(ctx, schedule.ID, schedule.CurrentID, next)
+	if errors.Is(err, errs.ErrScheduleNotActive) {
+		return
+	}
+	if err != nil {
+		p.logger.LogError("consumer — failed to advance schedule", err,
+			"scheduleID", schedule.ID, "layer", "broker.delivery")
+		return
+	}
+
+	if next == nil {
+		p.logger.Debug("consumer — schedule finished", "scheduleID", schedule.ID, "layer", "broker.delivery")
+	}
+
+}
+
+// RecoverSchedules advances active schedules whose current occurrence was processed
+// without the next one being produced, e.g. because the service stopped in between.
+func (p *Processor) RecoverSchedules(ctx context.Context) {
+
+	schedules, err := p.storage.StaleSchedules(ctx)
+	if err != nil {
+		p.logger.LogError("consumer — failed to recover schedules from db", err, "layer", "broker.delivery")
+		return
+	}
+
+	for _, schedule := range schedules {
+		p.advanceSchedule(ctx, schedule)
+	}
+
+}
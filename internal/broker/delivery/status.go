@@ -0,0 +1,139 @@
+package delivery
+
+import (
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"context"
+	"time"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// updateRecipients records the result of a delivery attempt for every recipient
+// that had not received the notification yet, and reports whether at least one
+// recipient has received it so far. If sendErr does not name the failed recipients,
+// the attempt is considered failed for all of them.
+func (p *Processor) updateRecipients(ctx context.Context, notification models.Notification, sendErr error) bool {
+
+	failed, perRecipient := notifier.FailedRecipients(sendErr)
+
+	var delivered bool
+	var updated []models.Recipient
+
+	now := time.Now().UTC()
+
+	for _, recipient := range notification.Recipients {
+
+		if recipient.Status == models.StatusSent {
+			delivered = true
+			continue
+		}
+
+		recipient.Attempts++
+
+		var reason error
+		switch {
+		case perRecipient:
+			reason = failed[recipient.Address]
+		case sendErr != nil:
+			reason = sendErr
+		}
+
+		if reason != nil {
+			recipient.Status = models.StatusFailed
+			recipient.LastError = reason.Error()
+		} else {
+			recipient.Status = models.StatusSent
+			recipient.LastError = ""
+			recipient.DeliveredAt = &now
+			delivered = true
+		}
+
+		updated = append(updated, recipient)
+
+	}
+
+	if len(updated) == 0 {
+		return delivered
+	}
+
+	if err := retry.DoContext(ctx, retry.Strategy{
+		Attempts: p.config.Consumer.Attempts,
+		Delay:    p.config.Consumer.Delay,
+		Backoff:  p.config.Consumer.Backoff,
+	}, func() error { return p.storage.UpdateRecipients(ctx, notification.ID, updated) }); err != nil {
+		p.logger.LogError("consumer — failed to update recipient statuses in db",
+			err, "notificationID", notification.ID, "layer", "broker.delivery")
+	}
+
+	return delivered
+
+}
+
+// updateStatus updates the notification status in both cache and storage.
+// It applies automatic transformations: Pending → Sent, Late → Sent.
+// Lateness within the delivery deadline is not a failure; see expired.
+func (p *Processor) updateStatus(ctx context.Context, notificationID string, status string) error {
+
+	if status == models.StatusPending || status == models.StatusLate {
+		status = models.StatusSent
+	}
+
+	return p.setStatus(ctx, notificationID, status)
+
+}
+
+// setStatus writes the notification status to cache and storage as is, retrying according to
+// the configured consumer strategy. A failure to update the cache is only logged, while a failure
+// to update storage is returned, so that the notification is not released before the status is persisted.
+func (p *Processor) setStatus(ctx context.Context, notificationID string, status string) error {
+
+	p.cacheStatus(ctx, notificationID, status)
+
+	if err := retry.DoContext(ctx, retry.Strategy{
+		Attempts: p.config.Consumer.Attempts,
+		Delay:    p.config.Consumer.Delay,
+		Backoff:  p.config.Consumer.Backoff,
+	}, func() error { return p.storage.SetStatus(ctx, notificationID, status) }); err != nil {
+		p.logger.LogError("consumer — failed to update notification status in db",
+			err, "notificationID", notificationID, "layer", "broker.delivery")
+		return err
+	}
+
+	return nil
+
+}
+
+// cacheStatus writes the notification status to cache, retrying according to the configured
+// consumer strategy. A failure is only logged.
+func (p *Processor) cacheStatus(ctx context.Context, notificationID string, status string) {
+
+	if err := retry.DoContext(ctx, retry.Strategy{
+		Attempts: p.config.Consumer.Attempts,
+		Delay:    p.config.Consumer.Delay,
+		Backoff:  p.config.Consumer.Backoff,
+	}, func() error {
+		return p.cache.SetStatus(ctx, notificationID, status)
+	}); err != nil {
+		p.logger.LogError("consumer — failed to update notification status in cache",
+			err, "notificationID", notificationID, "layer", "broker.delivery")
+	}
+
+}
+
+// setAttempts records the number of delivery attempts of a notification in storage,
+// retrying according to the configured consumer strategy.
+func (p *Processor) setAttempts(ctx context.Context, notification models.Notification) {
+
+	if err := retry.DoContext(ctx, retry.Strategy{
+		Attempts: p.config.Consumer.Attempts,
+		Delay:    p.config.Consumer.Delay,
+		Backoff:  p.config.Consumer.Backoff,
+	}, func() error {
+		return p.storage.SetAttempts(ctx, notification.ID, notification.Attempts)
+	}); err != nil {
+		p.logger.LogError("consumer — failed to update delivery attempts in db",
+			err, "notificationID", notification.ID, "layer", "broker.delivery")
+	}
+
+}
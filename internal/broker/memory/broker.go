@@ -0,0 +1,166 @@
+// Package memory provides an in-process broker.Broker implementation for development and tests.
+// Notifications wait in a time-ordered heap until their send time and are delivered by a pool of
+// goroutines, so the full flow runs without RabbitMQ. Nothing survives a restart except what is in
+// storage: on start, the broker re-queues pending and late notifications like recovery does.
+package memory
+
+import (
+	"Chronos/internal/broker/delivery"
+	"Chronos/internal/cache"
+	"Chronos/internal/config"
+	"Chronos/internal/logger"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/repository"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wb-go/wbf/helpers"
+)
+
+const (
+	defaultWorkers       = 1
+	defaultRelayInterval = 200 * time.Millisecond
+	redeliveryDelay      = time.Second
+)
+
+// Broker is an in-memory implementation of the Broker interface.
+type Broker struct {
+	logger    logger.Logger       // structured logger for logging broker events
+	config    config.Broker       // broker configuration
+	storage   repository.Storage  // storage interface for persistent storage
+	processor *delivery.Processor // delivers claimed notifications
+	queue     *queue              // notifications waiting for their send time
+	ctx       context.Context     // context canceled on shutdown
+	cancel    context.CancelFunc  // cancels ctx
+	wg        sync.WaitGroup      // tracks the dispatcher, the workers, the relay and the maintenance loop
+}
+
+// NewBroker creates an in-memory broker that delivers notifications within the process.
+func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) (*Broker, error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &Broker{
+		logger:  logger,
+		config:  config,
+		storage: storage,
+		queue:   newQueue(),
+		ctx:     ctx,
+		cancel:  cancel}
+
+	b.processor = delivery.NewProcessor(logger, config, cache, storage, notifier, b)
+
+	return b, nil
+
+}
+
+// Consume re-queues the notifications left in storage, starts the relay, the maintenance loop,
+// the workers and the dispatcher that feeds them, and blocks until the broker is shut down.
+func (b *Broker) Consume() error {
+
+	b.recover(b.ctx)
+
+	workers := max(b.config.Consumer.Workers, defaultWorkers)
+	jobs := make(chan models.Notification)
+
+	b.wg.Go(func() { b.relay(b.ctx) })
+	b.wg.Go(func() { b.maintain(b.ctx) })
+
+	for range workers {
+		b.wg.Go(func() { b.work(b.ctx, jobs) })
+	}
+
+	b.wg.Go(func() {
+		b.dispatch(b.ctx, jobs)
+		close(jobs)
+	})
+
+	<-b.ctx.Done()
+
+	return nil
+
+}
+
+// Produce queues a notification until its send time.
+func (b *Broker) Produce(notification models.Notification) error {
+	b.queue.push(notification, notification.SendAt)
+	return nil
+}
+
+// Reschedule queues a rescheduled notification for its new send time. The entry of the previous
+// revision stays in the queue and is discarded once it is due, since its revision no longer matches.
+func (b *Broker) Reschedule(notification models.Notification) error {
+	return b.Produce(notification)
+}
+
+// Retry queues another delivery attempt of a notification that failed to be delivered, due once delay has passed.
+func (b *Broker) Retry(notification models.Notification, delay time.Duration) error {
+	b.queue.push(notification, time.Now().Add(delay))
+	return nil
+}
+
+// DeadLetter saves a notification that could not be delivered as a dead letter, where it can be
+// inspected and replayed. A failure is only logged: the notification keeps its terminal status in storage.
+func (b *Broker) DeadLetter(body []byte, notificationID string, attempts int, reason error) {
+
+	deadLetter := models.DeadLetter{
+		ID:             helpers.CreateUUID(),
+		NotificationID: notificationID,
+		Reason:         reason.Error(),
+		Body:           string(body),
+		Attempts:       attempts,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := b.storage.CreateDeadLetter(b.ctx, deadLetter); err != nil {
+		b.logger.LogError("consumer — failed to save dead letter", err,
+			"notificationID", notificationID, "reason", reason.Error(), "layer", "broker.memory")
+	}
+
+}
+
+// Shutdown stops dispatching and waits for the workers to finish the notifications they are sending.
+// Queued notifications are dropped; they are still pending in storage and are re-queued on the next start.
+func (b *Broker) Shutdown() {
+	b.cancel()
+	b.wg.Wait()
+	b.logger.LogInfo("memory — broker shutdown complete", "layer", "broker.memory")
+}
+
+// recover queues the pending and late notifications found in storage, which an in-memory queue
+// has lost with the previous process, and advances schedules whose occurrence was not followed up.
+func (b *Broker) recover(ctx context.Context) {
+
+	notifications, err := b.storage.Recover(ctx)
+	if err != nil {
+		b.logger.LogError("memory — failed to recover notifications from db", err, "layer", "broker.memory")
+	}
+
+	for _, notification := range notifications {
+		b.queue.push(notification, notification.SendAt)
+	}
+
+	b.processor.RecoverSchedules(ctx)
+
+}
+
+// maintain cleans up outdated rows and advances stale recurring schedules on every cleanup interval
+// until ctx is canceled.
+func (b *Broker) maintain(ctx context.Context) {
+
+	ticker := time.NewTicker(b.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.storage.Cleanup(ctx)
+			b.processor.RecoverSchedules(ctx)
+		}
+	}
+
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"Chronos/internal/broker/delivery"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// dispatch hands due notifications to the workers in send time order until ctx is canceled.
+// Between notifications it sleeps until the earliest one is due or a new one is queued.
+func (b *Broker) dispatch(ctx context.Context, jobs chan<- models.Notification) {
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+
+		notification, wait, due := b.queue.next(time.Now())
+
+		if due {
+			select {
+			case jobs <- notification:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		var timeout <-chan time.Time
+		if wait >= 0 {
+			timer.Reset(wait)
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.queue.wake:
+		case <-timeout:
+		}
+
+	}
+
+}
+
+// work processes the notifications handed over by the dispatcher until the jobs channel is closed.
+// A notification that could not be processed is queued again after a short delay, the way
+// RabbitMQ redelivers a message that was not acknowledged.
+func (b *Broker) work(ctx context.Context, jobs <-chan models.Notification) {
+	for notification := range jobs {
+		if err := b.handler(ctx, notification); err != nil && ctx.Err() == nil {
+			b.logger.LogError("consumer — failed to process notification, queueing it again", err,
+				"notificationID", notification.ID, "layer", "broker.memory")
+			b.queue.push(notification, time.Now().Add(redeliveryDelay))
+		}
+	}
+}
+
+// handler processes a single due notification the same way the RabbitMQ consumer processes a message.
+// It discards the notification if it has been deleted by cleanup, has been rescheduled since it was
+// queued or has already been processed, advances the schedule of a canceled occurrence, claims the
+// notification as sending so that a duplicate entry does not send it twice, and hands it over to the
+// delivery processor.
+func (b *Broker) handler(ctx context.Context, notification models.Notification) error {
+
+	stored, err := b.storage.GetNotification(ctx, notification.ID)
+	if errors.Is(err, errs.ErrNotificationNotFound) {
+		b.logger.Debug("consumer — notification no longer exists, discarding entry",
+			"notificationID", notification.ID, "layer", "broker.memory")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if stored.Revision != notification.Revision {
+		b.logger.Debug("consumer — discarding entry of a rescheduled notification",
+			"notificationID", notification.ID, "revision", notification.Revision, "layer", "broker.memory")
+		return nil
+	}
+
+	if stored.Status == models.StatusCanceled {
+		b.processor.Advance(ctx, notification)
+		return nil
+	}
+
+	if !delivery.Claimable(stored.Status) {
+		b.logger.Debug("consumer — notification has already been processed, discarding duplicate entry",
+			"notificationID", notification.ID, "status", stored.Status, "layer", "broker.memory")
+		return nil
+	}
+
+	status, err := b.storage.ClaimNotification(ctx, notification.ID, notification.Revision, b.processor.ClaimTimeout())
+	if errors.Is(err, errs.ErrNotificationClaimed) {
+		b.queue.push(notification, time.Now().Add(b.processor.ClaimTimeout()))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return b.processor.Deliver(ctx, notification, status)
+
+}
@@ -0,0 +1,428 @@
+package memory_test
+
+import (
+	"Chronos/internal/broker/memory"
+	cacheMocks "Chronos/internal/cache/mocks"
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/logger"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/repository"
+	"Chronos/internal/service/impl"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// storage keeps notifications in memory and implements the part of repository.Storage
+// used when creating, canceling and delivering plain notifications.
+type storage struct {
+	repository.Storage
+	mu            sync.Mutex
+	notifications map[string]models.Notification
+	recipients    map[string][]models.Recipient
+	outbox        []models.OutboxEntry
+	entries       int64
+	deadLetters   []models.DeadLetter
+	lookups       int
+}
+
+func newStorage() *storage {
+	return &storage{notifications: make(map[string]models.Notification), recipients: make(map[string][]models.Recipient)}
+}
+
+func (s *storage) CreateNotification(ctx context.Context, notification models.Notification) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notifications[notification.ID] = notification
+	for _, address := range notification.SendTo {
+		s.recipients[notification.ID] = append(s.recipients[notification.ID],
+			models.Recipient{Address: address, Kind: "to", Status: models.StatusPending})
+	}
+	s.entries++
+	s.outbox = append(s.outbox, models.OutboxEntry{ID: s.entries, Notification: models.Notification{ID: notification.ID}})
+
+	return nil
+
+}
+
+func (s *storage) GetNotification(ctx context.Context, notificationID string) (models.Notification, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lookups++
+
+	notification, ok := s.notifications[notificationID]
+	if !ok {
+		return models.Notification{}, errs.ErrNotificationNotFound
+	}
+
+	return notification, nil
+
+}
+
+func (s *storage) GetStatus(ctx context.Context, notificationID string) (string, error) {
+	notification, err := s.GetNotification(ctx, notificationID)
+	return notification.Status, err
+}
+
+func (s *storage) SetStatus(ctx context.Context, notificationID string, status string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification, ok := s.notifications[notificationID]
+	if !ok {
+		return errs.ErrNotificationNotFound
+	}
+
+	if status == models.StatusCanceled && notification.Status != models.StatusPending && notification.Status != models.StatusLate {
+		return errs.ErrCannotCancel
+	}
+
+	notification.Status = status
+	notification.UpdatedAt = time.Now()
+	s.notifications[notificationID] = notification
+
+	return nil
+
+}
+
+func (s *storage) SetAttempts(ctx context.Context, notificationID string, attempts int) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification := s.notifications[notificationID]
+	notification.Attempts = attempts
+	s.notifications[notificationID] = notification
+
+	return nil
+
+}
+
+func (s *storage) ClaimNotification(ctx context.Context, notificationID string, revision int, lease time.Duration) (string, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification := s.notifications[notificationID]
+	previous := notification.Status
+
+	switch {
+	case notification.Revision != revision:
+		return "", errs.ErrNotificationClaimed
+	case previous == models.StatusPending, previous == models.StatusLate:
+	case previous == models.StatusSending && time.Since(notification.UpdatedAt) > lease:
+	default:
+		return "", errs.ErrNotificationClaimed
+	}
+
+	notification.Status = models.StatusSending
+	notification.UpdatedAt = time.Now()
+	s.notifications[notificationID] = notification
+
+	return previous, nil
+
+}
+
+func (s *storage) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.recipients[notificationID]), nil
+}
+
+func (s *storage) UpdateRecipients(ctx context.Context, notificationID string, recipients []models.Recipient) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, updated := range recipients {
+		for i, recipient := range s.recipients[notificationID] {
+			if recipient.Address == updated.Address && recipient.Kind == updated.Kind {
+				s.recipients[notificationID][i] = updated
+			}
+		}
+	}
+
+	return nil
+
+}
+
+func (s *storage) CreateDeadLetter(ctx context.Context, deadLetter models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	return nil
+}
+
+func (s *storage) ClaimOutbox(ctx context.Context, lease time.Duration) ([]models.OutboxEntry, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]models.OutboxEntry, 0, len(s.outbox))
+	for _, entry := range s.outbox {
+		entries = append(entries, models.OutboxEntry{ID: entry.ID, Notification: s.notifications[entry.Notification.ID]})
+	}
+
+	return entries, nil
+
+}
+
+func (s *storage) MarkPublished(ctx context.Context, entryIDs []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = slices.DeleteFunc(s.outbox, func(entry models.OutboxEntry) bool { return slices.Contains(entryIDs, entry.ID) })
+	return nil
+}
+
+func (s *storage) Recover(ctx context.Context) ([]models.Notification, error) { return nil, nil }
+
+func (s *storage) StaleSchedules(ctx context.Context) ([]models.Schedule, error) { return nil, nil }
+
+func (s *storage) Cleanup(ctx context.Context) {}
+
+// channel records the notifications it sends, failing every send if err is set.
+type channel struct {
+	err  error
+	sent chan models.Notification
+}
+
+func (c *channel) Name() string { return "test" }
+
+func (c *channel) Send(ctx context.Context, notification models.Notification) error {
+	c.sent <- notification
+	return c.err
+}
+
+func (c *channel) Validate(notification models.Notification) error { return nil }
+
+func (c *channel) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{RequiresRecipients: true}
+}
+
+// setup wires the service to an in-memory broker and starts consuming.
+func setup(t *testing.T, sendErr error) (*impl.Service, *storage, *channel) {
+
+	controller := gomock.NewController(t)
+
+	cache := cacheMocks.NewMockCache(controller)
+	cache.EXPECT().GetStatus(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss")).AnyTimes()
+	cache.EXPECT().SetStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	log, _ := logger.NewLogger(config.Logger{})
+	storage := newStorage()
+	channel := &channel{err: sendErr, sent: make(chan models.Notification, 10)}
+
+	registry := notifier.NewRegistry(0, nil)
+	require.NoError(t, registry.Register(channel))
+
+	cfg := config.Broker{
+		Backend:         "memory",
+		CleanupInterval: time.Hour,
+		RelayInterval:   10 * time.Millisecond,
+		Consumer:        config.Consumer{Attempts: 1, Workers: 2},
+		Retry:           config.Retry{Attempts: 2, Delay: 50 * time.Millisecond, Backoff: 1},
+	}
+
+	broker, err := memory.NewBroker(log, cfg, cache, storage, registry)
+	require.NoError(t, err)
+
+	go func() { _ = broker.Consume() }()
+	t.Cleanup(broker.Shutdown)
+
+	return impl.NewService(log, broker, cache, storage, registry), storage, channel
+
+}
+
+func TestBroker_DeliversAtSendAt(t *testing.T) {
+
+	ctx := context.Background()
+	service, storage, channel := setup(t, nil)
+
+	sendAt := time.Now().Add(300 * time.Millisecond)
+
+	id, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test",
+		Message: "hello",
+		SendAt:  sendAt,
+		SendTo:  []string{"user"},
+	})
+	require.NoError(t, err)
+
+	select {
+	case sent := <-channel.sent:
+		require.Equal(t, id, sent.ID)
+		require.False(t, time.Now().Before(sendAt), "notification was sent before its send time")
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not sent")
+	}
+
+	require.Eventually(t, func() bool {
+		status, _ := storage.GetStatus(ctx, id)
+		return status == models.StatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+
+}
+
+func TestBroker_LateIsSent(t *testing.T) {
+
+	ctx := context.Background()
+	service, storage, channel := setup(t, nil)
+
+	id, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test",
+		Message: "hello",
+		SendAt:  time.Now().Add(200 * time.Millisecond),
+		SendTo:  []string{"user"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.SetStatus(ctx, id, models.StatusLate))
+
+	select {
+	case sent := <-channel.sent:
+		require.Equal(t, id, sent.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not sent")
+	}
+
+	require.Eventually(t, func() bool {
+		status, _ := storage.GetStatus(ctx, id)
+		return status == models.StatusSent
+	}, 5*time.Second, 10*time.Millisecond, "late notification delivered within its deadline is not sent")
+
+}
+
+func TestBroker_SendAtOrder(t *testing.T) {
+
+	ctx := context.Background()
+	service, _, channel := setup(t, nil)
+
+	now := time.Now()
+
+	late, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test", Message: "second", SendAt: now.Add(500 * time.Millisecond), SendTo: []string{"user"}})
+	require.NoError(t, err)
+
+	early, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test", Message: "first", SendAt: now.Add(200 * time.Millisecond), SendTo: []string{"user"}})
+	require.NoError(t, err)
+
+	for _, expected := range []string{early, late} {
+		select {
+		case sent := <-channel.sent:
+			require.Equal(t, expected, sent.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification was not sent")
+		}
+	}
+
+}
+
+func TestBroker_Canceled(t *testing.T) {
+
+	ctx := context.Background()
+	service, storage, channel := setup(t, nil)
+
+	id, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test",
+		Message: "hello",
+		SendAt:  time.Now().Add(200 * time.Millisecond),
+		SendTo:  []string{"user"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, service.CancelNotification(ctx, id))
+
+	select {
+	case <-channel.sent:
+		t.Fatal("canceled notification was sent")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	status, err := storage.GetStatus(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCanceled, status)
+
+}
+
+func TestBroker_RetriesAndDeadLetters(t *testing.T) {
+
+	ctx := context.Background()
+	service, storage, channel := setup(t, errors.New("provider unavailable"))
+
+	id, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test",
+		Message: "hello",
+		SendAt:  time.Now().Add(100 * time.Millisecond),
+		SendTo:  []string{"user"},
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		select {
+		case <-channel.sent:
+		case <-time.After(5 * time.Second):
+			t.Fatal("delivery was not attempted")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		status, _ := storage.GetStatus(ctx, id)
+		return status == models.StatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	require.Len(t, storage.deadLetters, 1)
+	require.Equal(t, id, storage.deadLetters[0].NotificationID)
+	require.Equal(t, 2, storage.deadLetters[0].Attempts)
+
+}
+
+func TestBroker_Deleted(t *testing.T) {
+
+	ctx := context.Background()
+	service, storage, channel := setup(t, nil)
+
+	id, err := service.CreateNotification(ctx, models.Notification{
+		Channel: "test",
+		Message: "hello",
+		SendAt:  time.Now().Add(200 * time.Millisecond),
+		SendTo:  []string{"user"},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		storage.mu.Lock()
+		defer storage.mu.Unlock()
+		return len(storage.outbox) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	storage.mu.Lock()
+	delete(storage.notifications, id)
+	storage.mu.Unlock()
+
+	select {
+	case <-channel.sent:
+		t.Fatal("deleted notification was sent")
+	case <-time.After(1500 * time.Millisecond):
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	require.Equal(t, 1, storage.lookups, "entry of a deleted notification was queued again")
+
+}
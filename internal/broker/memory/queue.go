@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"Chronos/internal/models"
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// item is a notification waiting in the queue until it is due.
+type item struct {
+	notification models.Notification // notification to deliver
+	at           time.Time           // time the notification is due
+	seq          uint64              // insertion order, keeps items due at the same time first in, first out
+}
+
+// timeline is a min-heap of items ordered by due time. It implements heap.Interface.
+type timeline []item
+
+func (t timeline) Len() int { return len(t) }
+
+func (t timeline) Less(i, j int) bool {
+	if t[i].at.Equal(t[j].at) {
+		return t[i].seq < t[j].seq
+	}
+	return t[i].at.Before(t[j].at)
+}
+
+func (t timeline) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+
+func (t *timeline) Push(x any) { *t = append(*t, x.(item)) }
+
+func (t *timeline) Pop() any {
+	old := *t
+	last := old[len(old)-1]
+	*t = old[:len(old)-1]
+	return last
+}
+
+// queue is a time-ordered queue of notifications, safe for concurrent use.
+type queue struct {
+	mu    sync.Mutex    // guards items and seq
+	items timeline      // waiting notifications, earliest first
+	seq   uint64        // sequence number of the next pushed item
+	wake  chan struct{} // signaled when an item is pushed, so the dispatcher recomputes its wait
+}
+
+// newQueue creates an empty queue.
+func newQueue() *queue {
+	return &queue{wake: make(chan struct{}, 1)}
+}
+
+// push adds a notification that becomes due at the given time.
+func (q *queue) push(notification models.Notification, at time.Time) {
+
+	q.mu.Lock()
+	heap.Push(&q.items, item{notification: notification, at: at, seq: q.seq})
+	q.seq++
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+}
+
+// next removes and returns the earliest notification if it is due at now. Otherwise it reports
+// how long until the earliest notification is due, or a negative duration if the queue is empty.
+func (q *queue) next(now time.Time) (models.Notification, time.Duration, bool) {
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return models.Notification{}, -1, false
+	}
+
+	if wait := q.items[0].at.Sub(now); wait > 0 {
+		return models.Notification{}, wait, false
+	}
+
+	return heap.Pop(&q.items).(item).notification, 0, true
+
+}
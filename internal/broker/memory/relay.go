@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+)
+
+// relay queues notifications from the outbox until ctx is canceled, so that notifications stored
+// by the service reach the in-memory queue the same way they reach RabbitMQ.
+func (b *Broker) relay(ctx context.Context) {
+
+	interval := b.config.RelayInterval
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		b.relayOutbox(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+	}
+
+}
+
+// relayOutbox claims unpublished outbox entries, queues their notifications and marks the entries as published.
+// Notifications that have been canceled or processed meanwhile are marked without being queued.
+func (b *Broker) relayOutbox(ctx context.Context) {
+
+	entries, err := b.storage.ClaimOutbox(ctx, b.config.RelayClaimTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.logger.LogError("relay — failed to get outbox from db", err, "layer", "broker.memory")
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	published := make([]int64, 0, len(entries))
+
+	for _, entry := range entries {
+		if status := entry.Notification.Status; status == models.StatusPending || status == models.StatusLate {
+			b.queue.push(entry.Notification, entry.Notification.SendAt)
+		}
+		published = append(published, entry.ID)
+	}
+
+	if err := b.storage.MarkPublished(ctx, published); err != nil && ctx.Err() == nil {
+		b.logger.LogError("relay — failed to mark outbox entries as published", err,
+			"count", len(published), "layer", "broker.memory")
+	}
+
+}
@@ -68,6 +68,20 @@ func (mr *MockBrokerMockRecorder) Produce(notification any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Produce", reflect.TypeOf((*MockBroker)(nil).Produce), notification)
 }
 
+// Reschedule mocks base method.
+func (m *MockBroker) Reschedule(notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockBrokerMockRecorder) Reschedule(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockBroker)(nil).Reschedule), notification)
+}
+
 // Shutdown mocks base method.
 func (m *MockBroker) Shutdown() {
 	m.ctrl.T.Helper()
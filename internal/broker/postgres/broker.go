@@ -0,0 +1,122 @@
+// Package postgres provides a broker.Broker implementation that needs no message broker.
+// Notifications are delivered straight from the Notifications table: a poller claims due rows
+// and hands them to a pool of workers, so the table itself acts as the delay queue.
+package postgres
+
+import (
+	"Chronos/internal/broker/delivery"
+	"Chronos/internal/cache"
+	"Chronos/internal/config"
+	"Chronos/internal/logger"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/repository"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wb-go/wbf/helpers"
+)
+
+const (
+	defaultPollInterval  = time.Second
+	defaultWorkers       = 1
+	defaultRelayInterval = time.Second
+)
+
+// Broker is a Postgres implementation of the Broker interface.
+// Produce and Reschedule have nothing to do, since the stored notification is all the poller needs.
+type Broker struct {
+	logger    logger.Logger       // structured logger for logging broker events
+	config    config.Broker       // broker configuration
+	storage   repository.Storage  // storage the notifications are polled from
+	processor *delivery.Processor // delivers claimed notifications
+	ctx       context.Context     // context canceled on shutdown
+	cancel    context.CancelFunc  // cancels ctx
+	wg        sync.WaitGroup      // tracks the poller, the workers and the maintenance loop
+}
+
+// NewBroker creates a Postgres broker that delivers notifications from storage.
+func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) (*Broker, error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &Broker{
+		logger:  logger,
+		config:  config,
+		storage: storage,
+		ctx:     ctx,
+		cancel:  cancel}
+
+	b.processor = delivery.NewProcessor(logger, config, cache, storage, notifier, b)
+
+	return b, nil
+
+}
+
+// Consume starts the maintenance loop, the workers and the poller that feeds them,
+// and blocks until the broker is shut down.
+func (b *Broker) Consume() error {
+
+	workers := max(b.config.Poller.Workers, defaultWorkers)
+	jobs := make(chan models.Notification)
+
+	b.wg.Go(func() { b.maintain(b.ctx) })
+
+	for range workers {
+		b.wg.Go(func() { b.work(b.ctx, jobs) })
+	}
+
+	b.wg.Go(func() {
+		b.poll(b.ctx, jobs)
+		close(jobs)
+	})
+
+	<-b.ctx.Done()
+
+	return nil
+
+}
+
+// Produce does nothing: a stored notification is claimed by the poller once it is due.
+func (b *Broker) Produce(notification models.Notification) error {
+	return nil
+}
+
+// Reschedule does nothing: the poller picks up the new send time from storage.
+func (b *Broker) Reschedule(notification models.Notification) error {
+	return nil
+}
+
+// Retry delays the next claim of a notification that failed to be delivered until delay has passed.
+// The processor releases the claim afterwards, so the notification is not claimed again before that.
+func (b *Broker) Retry(notification models.Notification, delay time.Duration) error {
+	return b.storage.SetAvailableAt(b.ctx, notification.ID, time.Now().Add(delay))
+}
+
+// DeadLetter saves a notification that could not be delivered as a dead letter, where it can be
+// inspected and replayed. A failure is only logged: the notification keeps its terminal status in storage.
+func (b *Broker) DeadLetter(body []byte, notificationID string, attempts int, reason error) {
+
+	deadLetter := models.DeadLetter{
+		ID:             helpers.CreateUUID(),
+		NotificationID: notificationID,
+		Reason:         reason.Error(),
+		Body:           string(body),
+		Attempts:       attempts,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := b.storage.CreateDeadLetter(b.ctx, deadLetter); err != nil {
+		b.logger.LogError("consumer — failed to save dead letter", err,
+			"notificationID", notificationID, "reason", reason.Error(), "layer", "broker.postgres")
+	}
+
+}
+
+// Shutdown stops polling and waits for the workers to finish the notifications they are sending.
+func (b *Broker) Shutdown() {
+	b.cancel()
+	b.wg.Wait()
+	b.logger.LogInfo("postgres — broker shutdown complete", "layer", "broker.postgres")
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+)
+
+// poll claims due notifications and hands them to the workers until ctx is canceled.
+// After a full batch it polls again right away; otherwise it waits for the poll interval.
+func (b *Broker) poll(ctx context.Context, jobs chan<- models.Notification) {
+
+	interval := b.config.Poller.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	batch := max(b.config.Poller.BatchSize, 1)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		claimed, err := b.storage.ClaimDue(ctx, batch, b.processor.ClaimTimeout())
+		if err != nil && ctx.Err() == nil {
+			b.logger.LogError("poller — failed to claim due notifications", err, "layer", "broker.postgres")
+		}
+
+		if !b.dispatch(ctx, jobs, claimed) {
+			return
+		}
+
+		if len(claimed) == batch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+	}
+
+}
+
+// dispatch hands claimed notifications to the workers one by one. If ctx is canceled first,
+// the notifications no worker has taken are released back to their previous status,
+// and dispatch reports false.
+func (b *Broker) dispatch(ctx context.Context, jobs chan<- models.Notification, claimed []models.Notification) bool {
+
+	for i, notification := range claimed {
+		select {
+		case jobs <- notification:
+		case <-ctx.Done():
+			b.release(claimed[i:])
+			return false
+		}
+	}
+
+	return ctx.Err() == nil
+
+}
+
+// release puts claimed notifications that were never handed to a worker, or whose delivery was interrupted
+// by shutdown, back into their previous status, so that the next start delivers them without waiting for their
+// claims to expire. A notification taken over from an expired claim goes back to pending. It runs during shutdown
+// and therefore does not use the canceled broker context. A failure is only logged: the claim then expires.
+func (b *Broker) release(notifications []models.Notification) {
+	for _, notification := range notifications {
+		status := notification.Status
+		if status == models.StatusSending {
+			status = models.StatusPending
+		}
+		if err := b.storage.SetStatus(context.Background(), notification.ID, status); err != nil {
+			b.logger.LogError("poller — failed to release claimed notification", err,
+				"notificationID", notification.ID, "layer", "broker.postgres")
+		}
+	}
+}
+
+// work delivers the notifications handed over by the poller until the jobs channel is closed.
+// A notification whose delivery was interrupted by shutdown is released; one whose outcome could
+// not be recorded otherwise stays claimed and is taken over once its claim expires.
+func (b *Broker) work(ctx context.Context, jobs <-chan models.Notification) {
+
+	for notification := range jobs {
+
+		err := b.processor.Deliver(ctx, notification, notification.Status)
+
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			b.release([]models.Notification{notification})
+		default:
+			b.logger.LogError("consumer — failed to process notification, leaving it until its claim expires", err,
+				"notificationID", notification.ID, "layer", "broker.postgres")
+		}
+
+	}
+
+}
+
+// maintain runs periodic maintenance until ctx is canceled: it cleans up outdated rows and advances
+// recurring schedules whose current occurrence was canceled or interrupted, and marks outbox entries
+// as published, since the poller reads the notifications directly and there is nothing to relay.
+func (b *Broker) maintain(ctx context.Context) {
+
+	relayInterval := b.config.RelayInterval
+	if relayInterval <= 0 {
+		relayInterval = defaultRelayInterval
+	}
+
+	b.storage.Cleanup(ctx)
+	b.processor.RecoverSchedules(ctx)
+
+	cleaner := time.NewTicker(b.config.CleanupInterval)
+	relay := time.NewTicker(relayInterval)
+
+	defer cleaner.Stop()
+	defer relay.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleaner.C:
+			b.storage.Cleanup(ctx)
+			b.processor.RecoverSchedules(ctx)
+		case <-relay.C:
+			b.drainOutbox(ctx)
+		}
+	}
+
+}
+
+// drainOutbox claims the outbox entries of stored notifications and marks them as published.
+func (b *Broker) drainOutbox(ctx context.Context) {
+
+	entries, err := b.storage.ClaimOutbox(ctx, b.config.RelayClaimTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.logger.LogError("relay — failed to get outbox from db", err, "layer", "broker.postgres")
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	if err := b.storage.MarkPublished(ctx, ids); err != nil && ctx.Err() == nil {
+		b.logger.LogError("relay — failed to mark outbox entries as published", err, "layer", "broker.postgres")
+	}
+
+}
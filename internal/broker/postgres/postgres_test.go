@@ -0,0 +1,421 @@
+package postgres_test
+
+import (
+	"Chronos/internal/broker/postgres"
+	cacheMocks "Chronos/internal/cache/mocks"
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/logger"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/repository"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// storage keeps notifications in memory and implements the part of repository.Storage
+// the postgres broker polls, claims and delivers plain notifications through.
+type storage struct {
+	repository.Storage
+	mu            sync.Mutex
+	notifications map[string]models.Notification
+	recipients    map[string][]models.Recipient
+	availableAt   map[string]time.Time
+	outbox        []models.OutboxEntry
+	entries       int64
+	deadLetters   []models.DeadLetter
+}
+
+func newStorage() *storage {
+	return &storage{
+		notifications: make(map[string]models.Notification),
+		recipients:    make(map[string][]models.Recipient),
+		availableAt:   make(map[string]time.Time),
+	}
+}
+
+// add stores a notification together with its recipients and an outbox entry.
+func (s *storage) add(notification models.Notification) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if notification.UpdatedAt.IsZero() {
+		notification.UpdatedAt = time.Now()
+	}
+
+	s.notifications[notification.ID] = notification
+	for _, address := range notification.SendTo {
+		s.recipients[notification.ID] = append(s.recipients[notification.ID],
+			models.Recipient{Address: address, Kind: models.RecipientTo, Status: models.StatusPending})
+	}
+
+	s.entries++
+	s.outbox = append(s.outbox, models.OutboxEntry{ID: s.entries, Notification: models.Notification{ID: notification.ID}})
+
+}
+
+func (s *storage) status(notificationID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notifications[notificationID].Status
+}
+
+func (s *storage) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	var due []models.Notification
+
+	for id, notification := range s.notifications {
+
+		at := notification.SendAt
+		if availableAt, ok := s.availableAt[id]; ok {
+			at = availableAt
+		}
+
+		switch {
+		case (notification.Status == models.StatusPending || notification.Status == models.StatusLate) && !at.After(now):
+		case notification.Status == models.StatusSending && now.Sub(notification.UpdatedAt) > lease:
+		default:
+			continue
+		}
+
+		due = append(due, notification)
+
+	}
+
+	slices.SortFunc(due, func(a, b models.Notification) int { return a.SendAt.Compare(b.SendAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for _, notification := range due {
+		notification.Status = models.StatusSending
+		notification.UpdatedAt = now
+		s.notifications[notification.ID] = notification
+	}
+
+	return due, nil
+
+}
+
+func (s *storage) SetStatus(ctx context.Context, notificationID string, status string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification, ok := s.notifications[notificationID]
+	if !ok {
+		return errs.ErrNotificationNotFound
+	}
+
+	notification.Status = status
+	notification.UpdatedAt = time.Now()
+	s.notifications[notificationID] = notification
+
+	return nil
+
+}
+
+func (s *storage) SetAvailableAt(ctx context.Context, notificationID string, availableAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.availableAt[notificationID] = availableAt
+	return nil
+}
+
+func (s *storage) SetAttempts(ctx context.Context, notificationID string, attempts int) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification := s.notifications[notificationID]
+	notification.Attempts = attempts
+	s.notifications[notificationID] = notification
+
+	return nil
+
+}
+
+func (s *storage) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.recipients[notificationID]), nil
+}
+
+func (s *storage) UpdateRecipients(ctx context.Context, notificationID string, recipients []models.Recipient) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, updated := range recipients {
+		for i, recipient := range s.recipients[notificationID] {
+			if recipient.Address == updated.Address && recipient.Kind == updated.Kind {
+				s.recipients[notificationID][i] = updated
+			}
+		}
+	}
+
+	return nil
+
+}
+
+func (s *storage) CreateDeadLetter(ctx context.Context, deadLetter models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	return nil
+}
+
+func (s *storage) ClaimOutbox(ctx context.Context, lease time.Duration) ([]models.OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.outbox), nil
+}
+
+func (s *storage) MarkPublished(ctx context.Context, entryIDs []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = slices.DeleteFunc(s.outbox, func(entry models.OutboxEntry) bool { return slices.Contains(entryIDs, entry.ID) })
+	return nil
+}
+
+func (s *storage) StaleSchedules(ctx context.Context) ([]models.Schedule, error) { return nil, nil }
+
+func (s *storage) Cleanup(ctx context.Context) {}
+
+// channel records the notifications it sends, failing every send if err is set.
+// If block is set, every send waits until its context is canceled.
+type channel struct {
+	err   error
+	block bool
+	sent  chan models.Notification
+}
+
+func (c *channel) Name() string { return "test" }
+
+func (c *channel) Send(ctx context.Context, notification models.Notification) error {
+	c.sent <- notification
+	if c.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.err
+}
+
+func (c *channel) Validate(notification models.Notification) error { return nil }
+
+func (c *channel) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{RequiresRecipients: true}
+}
+
+// setup starts a postgres broker polling the in-memory storage and shuts it down when the test ends.
+func setup(t *testing.T, channel *channel, poller config.Poller) (*postgres.Broker, *storage) {
+
+	controller := gomock.NewController(t)
+
+	cache := cacheMocks.NewMockCache(controller)
+	cache.EXPECT().SetStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	log, _ := logger.NewLogger(config.Logger{})
+	storage := newStorage()
+
+	registry := notifier.NewRegistry(0, nil)
+	require.NoError(t, registry.Register(channel))
+
+	cfg := config.Broker{
+		Backend:         "postgres",
+		CleanupInterval: time.Hour,
+		RelayInterval:   10 * time.Millisecond,
+		Consumer:        config.Consumer{Attempts: 1, ClaimTimeout: time.Hour},
+		Retry:           config.Retry{Attempts: 2, Delay: 50 * time.Millisecond, Backoff: 1},
+		Poller:          poller,
+	}
+
+	broker, err := postgres.NewBroker(log, cfg, cache, storage, registry)
+	require.NoError(t, err)
+
+	go func() { _ = broker.Consume() }()
+
+	t.Cleanup(broker.Shutdown)
+
+	return broker, storage
+
+}
+
+func newChannel(err error) *channel {
+	return &channel{err: err, sent: make(chan models.Notification, 10)}
+}
+
+func newNotification(id string, sendAt time.Time) models.Notification {
+	return models.Notification{
+		ID:      id,
+		Channel: "test",
+		Message: "hello",
+		Status:  models.StatusPending,
+		SendAt:  sendAt,
+		SendTo:  []string{"user"},
+	}
+}
+
+func TestBroker_DeliversAtSendAt(t *testing.T) {
+
+	channel := newChannel(nil)
+	_, storage := setup(t, channel, config.Poller{Interval: 10 * time.Millisecond, BatchSize: 10, Workers: 2})
+
+	sendAt := time.Now().Add(300 * time.Millisecond)
+	storage.add(newNotification("due", sendAt))
+
+	select {
+	case sent := <-channel.sent:
+		require.Equal(t, "due", sent.ID)
+		require.False(t, time.Now().Before(sendAt), "notification was sent before its send time")
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not sent")
+	}
+
+	require.Eventually(t, func() bool {
+		return storage.status("due") == models.StatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		storage.mu.Lock()
+		defer storage.mu.Unlock()
+		return len(storage.outbox) == 0
+	}, 5*time.Second, 10*time.Millisecond, "outbox entry was not drained")
+
+}
+
+func TestBroker_ClaimsOnce(t *testing.T) {
+
+	channel := newChannel(nil)
+	_, storage := setup(t, channel, config.Poller{Interval: 10 * time.Millisecond, BatchSize: 1, Workers: 4})
+
+	for _, id := range []string{"first", "second", "third"} {
+		storage.add(newNotification(id, time.Now()))
+	}
+
+	var sent []string
+	for range 3 {
+		select {
+		case notification := <-channel.sent:
+			sent = append(sent, notification.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification was not sent")
+		}
+	}
+
+	select {
+	case notification := <-channel.sent:
+		t.Fatalf("notification %s was sent twice", notification.ID)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.ElementsMatch(t, []string{"first", "second", "third"}, sent)
+
+}
+
+func TestBroker_TakesOverExpiredClaim(t *testing.T) {
+
+	channel := newChannel(nil)
+	_, storage := setup(t, channel, config.Poller{Interval: 10 * time.Millisecond, BatchSize: 10, Workers: 2})
+
+	stale := newNotification("stale", time.Now().Add(-time.Hour))
+	stale.Status = models.StatusSending
+	stale.UpdatedAt = time.Now().Add(-2 * time.Hour)
+	storage.add(stale)
+
+	fresh := newNotification("fresh", time.Now().Add(-time.Hour))
+	fresh.Status = models.StatusSending
+	fresh.UpdatedAt = time.Now()
+	storage.add(fresh)
+
+	select {
+	case sent := <-channel.sent:
+		require.Equal(t, "stale", sent.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification with an expired claim was not taken over")
+	}
+
+	select {
+	case sent := <-channel.sent:
+		t.Fatalf("notification %s was sent while its claim was held", sent.ID)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.Eventually(t, func() bool {
+		return storage.status("stale") == models.StatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, models.StatusSending, storage.status("fresh"))
+
+}
+
+func TestBroker_RetriesAndDeadLetters(t *testing.T) {
+
+	channel := newChannel(errors.New("provider unavailable"))
+	_, storage := setup(t, channel, config.Poller{Interval: 10 * time.Millisecond, BatchSize: 10, Workers: 2})
+
+	storage.add(newNotification("failing", time.Now()))
+
+	var attempts []time.Time
+	for range 2 {
+		select {
+		case <-channel.sent:
+			attempts = append(attempts, time.Now())
+		case <-time.After(5 * time.Second):
+			t.Fatal("delivery was not attempted")
+		}
+	}
+
+	require.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 50*time.Millisecond, "retry was not delayed")
+
+	require.Eventually(t, func() bool {
+		return storage.status("failing") == models.StatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	require.Len(t, storage.deadLetters, 1)
+	require.Equal(t, "failing", storage.deadLetters[0].NotificationID)
+	require.Equal(t, 2, storage.deadLetters[0].Attempts)
+
+}
+
+func TestBroker_ReleasesClaimsOnShutdown(t *testing.T) {
+
+	channel := newChannel(nil)
+	channel.block = true
+	broker, storage := setup(t, channel, config.Poller{Interval: 10 * time.Millisecond, BatchSize: 10, Workers: 1})
+
+	storage.add(newNotification("sending", time.Now().Add(-time.Second)))
+	late := newNotification("waiting", time.Now())
+	late.Status = models.StatusLate
+	storage.add(late)
+
+	select {
+	case sent := <-channel.sent:
+		require.Equal(t, "sending", sent.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not sent")
+	}
+
+	require.Equal(t, models.StatusSending, storage.status("waiting"), "second notification was not claimed")
+
+	broker.Shutdown()
+
+	require.Equal(t, models.StatusPending, storage.status("sending"), "interrupted notification was not released")
+	require.Equal(t, models.StatusLate, storage.status("waiting"), "undispatched notification was not released")
+
+}
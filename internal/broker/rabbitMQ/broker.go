@@ -3,13 +3,16 @@
 package rabbitmq
 
 import (
+	"Chronos/internal/broker/delivery"
 	"Chronos/internal/cache"
 	"Chronos/internal/config"
 	"Chronos/internal/logger"
+	"Chronos/internal/models"
 	"Chronos/internal/notifier"
 	"Chronos/internal/repository"
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/wb-go/wbf/rabbitmq"
@@ -19,26 +22,36 @@ import (
 )
 
 const (
-	mainExchange = "mainExchange"
-	contentType  = "application/json"
-	exchangeKind = "direct"
+	mainExchange       = "mainExchange"
+	deadLetterExchange = "deadLetterExchange"
+	contentType        = "application/json"
+	exchangeKind       = "direct"
 )
 
+// priorities lists the notification priorities, each of which is delivered from its own queue.
+var priorities = []string{models.PriorityHigh, models.PriorityNormal, models.PriorityBulk}
+
 // Broker is a RabbitMQ implementation of the Broker interface.
-// It holds references to logger, config, consumer, producer, cache, storage, notifier, and the underlying RabbitClient.
+// It holds references to logger, config, consumers, producer, cache, storage, notifier, and the underlying RabbitClient.
 type Broker struct {
-	logger   logger.Logger          // structured logger for logging broker events
-	config   config.Broker          // broker configuration
-	Consumer *rabbitmq.Consumer     // RabbitMQ consumer instance
-	producer *rabbitmq.Publisher    // RabbitMQ publisher instance
-	cache    cache.Cache            // cache interface for temporary storage
-	storage  repository.Storage     // storage interface for persistent storage
-	notifier notifier.Notifier      // notifier for sending notifications
-	client   *rabbitmq.RabbitClient // underlying RabbitMQ client
+	logger    logger.Logger          // structured logger for logging broker events
+	config    config.Broker          // broker configuration
+	consumers []*rabbitmq.Consumer   // RabbitMQ consumers, one per priority queue and one for dead letters
+	producer  *rabbitmq.Publisher    // RabbitMQ publisher instance
+	cache     cache.Cache            // cache interface for temporary storage
+	storage   repository.Storage     // storage interface for persistent storage
+	notifier  notifier.Notifier      // notifier for sending notifications
+	client    *rabbitmq.RabbitClient // underlying RabbitMQ client
+	processor *delivery.Processor    // delivers the notifications of consumed messages
+	buckets   sync.Map               // names of the delay buckets declared so far
 }
 
 // NewBroker creates and initializes a new RabbitMQ Broker instance.
-// It sets up the RabbitMQ client, exchange, queue, producer, and consumer.
+// It sets up the RabbitMQ client, exchange, producer, and a delivery queue with its own consumer
+// for every priority, so that a burst of bulk notifications does not delay high priority ones.
+// Messages that cannot be delivered are collected in a dead-letter queue whose consumer saves them in storage.
+// Messages are acknowledged manually once their handler has returned, so a message whose outcome
+// has not been written to storage yet is redelivered rather than lost.
 func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) (*Broker, error) {
 
 	client, err := rabbitmq.NewClient(rabbitmq.ClientConfig{
@@ -66,9 +79,9 @@ func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, st
 		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	err = client.DeclareQueue(config.QueueName, mainExchange, config.QueueName, true, false, true, nil)
+	err = client.DeclareExchange(deadLetterExchange, exchangeKind, true, false, false, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to declare queue: %w", err)
+		return nil, fmt.Errorf("failed to declare dead-letter exchange: %w", err)
 	}
 
 	producer := rabbitmq.NewPublisher(client, mainExchange, contentType)
@@ -76,29 +89,94 @@ func NewBroker(logger logger.Logger, config config.Broker, cache cache.Cache, st
 	b := &Broker{
 		logger:   logger,
 		config:   config,
-		Consumer: nil,
 		producer: producer,
 		cache:    cache,
 		storage:  storage,
 		notifier: notifier,
 		client:   client}
 
-	b.Consumer = rabbitmq.NewConsumer(client, rabbitmq.ConsumerConfig{
-		Queue:         config.QueueName,
-		ConsumerTag:   config.Consumer.ConsumerTag,
-		AutoAck:       config.Consumer.AutoAck,
+	b.processor = delivery.NewProcessor(logger, config, cache, storage, notifier, b)
+
+	for _, priority := range priorities {
+
+		queue := b.deliveryQueue(priority)
+
+		err = client.DeclareQueue(queue, mainExchange, queue, true, false, true, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to declare queue %s: %w", queue, err)
+		}
+
+		settings := queueSettings(config.Consumer, priority)
+
+		b.consumers = append(b.consumers, rabbitmq.NewConsumer(client, rabbitmq.ConsumerConfig{
+			Queue:         queue,
+			ConsumerTag:   config.Consumer.ConsumerTag + "-" + priority,
+			AutoAck:       false,
+			Ask:           rabbitmq.AskConfig{Multiple: false},
+			Nack:          rabbitmq.NackConfig{Multiple: false, Requeue: true},
+			Args:          amqp.Table{},
+			Workers:       settings.Workers,
+			PrefetchCount: settings.PrefetchCount,
+		}, func(ctx context.Context, msg amqp.Delivery) error { return b.handler(ctx, msg) }))
+
+	}
+
+	if err := b.declareDelayStrategy(); err != nil {
+		return nil, err
+	}
+
+	deadLetters := b.deadLetterQueue()
+
+	err = client.DeclareQueue(deadLetters, deadLetterExchange, deadLetters, true, false, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare queue %s: %w", deadLetters, err)
+	}
+
+	b.consumers = append(b.consumers, rabbitmq.NewConsumer(client, rabbitmq.ConsumerConfig{
+		Queue:         deadLetters,
+		ConsumerTag:   config.Consumer.ConsumerTag + "-dead",
+		AutoAck:       false,
 		Ask:           rabbitmq.AskConfig{Multiple: false},
 		Nack:          rabbitmq.NackConfig{Multiple: false, Requeue: true},
 		Args:          amqp.Table{},
-		Workers:       config.Consumer.Workers,
+		Workers:       1,
 		PrefetchCount: config.Consumer.PrefetchCount,
-	}, func(ctx context.Context, msg amqp.Delivery) error { return b.handler(ctx, msg) })
+	}, func(ctx context.Context, msg amqp.Delivery) error { return b.recordDeadLetter(ctx, msg) }))
 
 	return b, nil
 
 }
 
+// deliveryQueue returns the name of the queue notifications of the given priority are delivered from.
+// Normal priority notifications, as well as notifications produced before priorities were
+// introduced, use the main queue; other priorities get a queue named after it, such as "main.high".
+func (b *Broker) deliveryQueue(priority string) string {
+	if priority == "" || priority == models.PriorityNormal {
+		return b.config.QueueName
+	}
+	return b.config.QueueName + "." + priority
+}
+
+// queueSettings returns the consumption settings of the given priority's queue,
+// falling back to the consumer-wide workers and prefetch count for anything not overridden.
+func queueSettings(consumer config.Consumer, priority string) config.Queue {
+
+	settings := consumer.Queues[priority]
+
+	if settings.Workers <= 0 {
+		settings.Workers = consumer.Workers
+	}
+
+	if settings.PrefetchCount <= 0 {
+		settings.PrefetchCount = consumer.PrefetchCount
+	}
+
+	return settings
+
+}
+
 // Shutdown gracefully closes the underlying RabbitMQ client and logs the outcome.
+// Closing the client cancels the consumer workers' context, which aborts in-flight sends.
 func (b *Broker) Shutdown() {
 	if err := b.client.Close(); err != nil {
 		b.logger.LogError("rabbit — failed to shutdown gracefully", err, "layer", "broker.rabbitMQ")
@@ -156,8 +234,8 @@ func (b *Broker) sysmon(ctx context.Context) {
 
 }
 
-// recover retrieves pending notifications from storage and re-queues them for processing.
-// It logs any errors encountered during recovery.
+// recover retrieves pending notifications from storage and re-queues them for processing,
+// then advances recurring schedules that were interrupted. It logs any errors encountered during recovery.
 func (b *Broker) recover(ctx context.Context) {
 	notifications, err := b.storage.Recover(ctx)
 	if err != nil {
@@ -169,5 +247,6 @@ func (b *Broker) recover(ctx context.Context) {
 			b.logger.LogError("rabbit — failed to produce notification", err, "notificationID", notification.ID, "layer", "broker.rabbitMQ")
 		}
 	}
+	b.processor.RecoverSchedules(ctx)
 	b.logger.Debug("rabbit — recovered", "layer", "broker.rabbitMQ")
 }
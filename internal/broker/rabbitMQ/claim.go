@@ -0,0 +1,27 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/models"
+	"fmt"
+)
+
+// postpone handles a message whose notification is currently claimed by another consumer. Instead of
+// being requeued in a hot loop, the message goes through a delay queue and comes back once the claim
+// could have expired: by then the notification has either been processed, and the message is discarded,
+// or its consumer has died without finishing, and the message takes the claim over.
+// An error is returned if the message cannot be published, so that it is requeued instead of lost.
+func (b *Broker) postpone(notification models.Notification, attempts int) error {
+
+	notification.Attempts = attempts
+	queue := queueName(notification.ID, notification.Revision) + ".claim"
+
+	if err := b.publishDelayed(notification, queue, b.processor.ClaimTimeout()); err != nil {
+		return fmt.Errorf("failed to postpone claimed notification: %w", err)
+	}
+
+	b.logger.Debug("consumer — notification is being sent by another consumer, postponing message",
+		"notificationID", notification.ID, "layer", "broker.rabbitMQ")
+
+	return nil
+
+}
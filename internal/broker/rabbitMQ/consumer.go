@@ -1,6 +1,8 @@
 package rabbitmq
 
 import (
+	"Chronos/internal/broker/delivery"
+	"Chronos/internal/errs"
 	"Chronos/internal/models"
 	"context"
 	"encoding/json"
@@ -10,18 +12,27 @@ import (
 
 	"github.com/rabbitmq/amqp091-go"
 	wbf "github.com/wb-go/wbf/rabbitmq"
-	"github.com/wb-go/wbf/retry"
 )
 
-// Consume starts the RabbitMQ consumer and system monitor in the background.
-// It returns an error if the consumer fails to start for reasons other than client closure or context cancellation.
+// Consume starts the system monitor and the outbox relay in the background and a consumer for every priority queue.
+// It blocks until all consumers stop, and returns as soon as one of them fails
+// for reasons other than client closure or context cancellation.
 func (b *Broker) Consume() error {
 
 	go b.sysmon(b.client.Context())
+	go b.relay(b.client.Context())
 
-	if err := b.Consumer.Start(b.client.Context()); err != nil &&
-		!errors.Is(err, wbf.ErrClientClosed) && !errors.Is(err, context.Canceled) {
-		return err
+	errCh := make(chan error, len(b.consumers))
+
+	for _, consumer := range b.consumers {
+		go func() { errCh <- consumer.Start(b.client.Context()) }()
+	}
+
+	for range b.consumers {
+		if err := <-errCh; err != nil &&
+			!errors.Is(err, wbf.ErrClientClosed) && !errors.Is(err, context.Canceled) {
+			return err
+		}
 	}
 
 	return nil
@@ -29,69 +40,65 @@ func (b *Broker) Consume() error {
 }
 
 // handler processes a single RabbitMQ delivery message.
-// It unmarshals the JSON payload into a Notification, checks its status,
-// attempts to send it via the notifier, and updates the status accordingly.
+// It unmarshals the JSON payload into a Notification, dead-lettering messages that cannot be
+// decoded, and delays messages of the buckets and exchange strategies that arrived before they
+// are due. It discards the message if the notification has been deleted by cleanup, has been
+// rescheduled since the message was produced or has already been processed, claims the
+// notification as sending so that duplicate messages do not send it twice, and hands it over
+// to the delivery processor.
+// The message is acknowledged only if the handler returns nil, that is after the outcome has been
+// written to storage; otherwise it is requeued, and the claim lets the redelivery pick up the work.
 func (b *Broker) handler(ctx context.Context, msg amqp091.Delivery) error {
 
 	var notification models.Notification
 
 	if err := json.Unmarshal(msg.Body, &notification); err != nil {
-		return fmt.Errorf("failed to unmarshal json: %w", err)
+		b.DeadLetter(msg.Body, "", deliveryAttempts(msg, 0), fmt.Errorf("failed to unmarshal json: %w", err))
+		return nil
 	}
 
-	status, err := b.storage.GetStatus(ctx, notification.ID)
+	if due, ok := dueTime(msg); ok && time.Now().Before(due) {
+		notification.Attempts = deliveryAttempts(msg, notification.Attempts)
+		return b.publishDelayed(notification, queueName(notification.ID, notification.Revision), time.Until(due))
+	}
+
+	stored, err := b.storage.GetNotification(ctx, notification.ID)
+	if errors.Is(err, errs.ErrNotificationNotFound) {
+		b.logger.Debug("consumer — notification no longer exists, discarding message",
+			"notificationID", notification.ID, "layer", "broker.rabbitMQ")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	if status != models.StatusCanceled {
-
-		if err := b.notifier.Notify(notification); err != nil {
-			if status != models.StatusFailed {
-				b.updateStatus(ctx, notification.ID, notification.SendAt, models.StatusFailed)
-			}
-			return err
-		}
-
-		b.updateStatus(ctx, notification.ID, notification.SendAt, status)
-
+	if stored.Revision != notification.Revision {
+		b.logger.Debug("consumer — discarding message of a rescheduled notification",
+			"notificationID", notification.ID, "revision", notification.Revision, "layer", "broker.rabbitMQ")
+		return nil
 	}
 
-	return nil
-
-}
-
-// updateStatus updates the notification status in both cache and storage.
-// It applies automatic transformations: Pending → Sent, Late or timed-out → FailedToSendInTime.
-// Updates are retried according to the configured retry strategy.
-func (b *Broker) updateStatus(ctx context.Context, notificationID string, sendAt time.Time, status string) {
-
-	if status == models.StatusPending {
-		status = models.StatusSent
+	if stored.Status == models.StatusCanceled {
+		b.processor.Advance(ctx, notification)
+		return nil
 	}
 
-	if status == models.StatusLate || time.Now().UTC().Sub(sendAt) > time.Minute {
-		status = models.StatusFailedToSendInTime
+	if !delivery.Claimable(stored.Status) {
+		b.logger.Debug("consumer — notification has already been processed, discarding duplicate message",
+			"notificationID", notification.ID, "status", stored.Status, "layer", "broker.rabbitMQ")
+		return nil
 	}
 
-	if err := retry.DoContext(ctx, retry.Strategy{
-		Attempts: b.config.Consumer.Attempts,
-		Delay:    b.config.Consumer.Delay,
-		Backoff:  b.config.Consumer.Backoff,
-	}, func() error {
-		return b.cache.SetStatus(ctx, notificationID, status)
-	}); err != nil {
-		b.logger.LogError("consumer — failed to update notification status in cache",
-			err, "notificationID", notificationID, "layer", "broker.rabbitMQ")
+	status, err := b.storage.ClaimNotification(ctx, notification.ID, notification.Revision, b.processor.ClaimTimeout())
+	if errors.Is(err, errs.ErrNotificationClaimed) {
+		return b.postpone(notification, deliveryAttempts(msg, notification.Attempts))
 	}
-
-	if err := retry.DoContext(ctx, retry.Strategy{
-		Attempts: b.config.Consumer.Attempts,
-		Delay:    b.config.Consumer.Delay,
-		Backoff:  b.config.Consumer.Backoff,
-	}, func() error { return b.storage.SetStatus(ctx, notificationID, status) }); err != nil {
-		b.logger.LogError("consumer — failed to update notification status in db",
-			err, "notificationID", notificationID, "layer", "broker.rabbitMQ")
+	if err != nil {
+		return err
 	}
 
+	notification.Attempts = deliveryAttempts(msg, notification.Attempts)
+
+	return b.processor.Deliver(ctx, notification, status)
+
 }
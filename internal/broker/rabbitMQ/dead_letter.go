@@ -0,0 +1,75 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/wb-go/wbf/helpers"
+	"github.com/wb-go/wbf/retry"
+)
+
+const (
+	reasonHeader         = "x-reason"          // AMQP header carrying the error that made a message a dead letter
+	notificationIDHeader = "x-notification-id" // AMQP header carrying the ID of the dead-lettered notification
+)
+
+// deadLetterQueue returns the name of the queue dead letters are collected in, such as "main.dead".
+func (b *Broker) deadLetterQueue() string {
+	return b.config.QueueName + ".dead"
+}
+
+// DeadLetter publishes a message that could not be delivered to the dead-letter exchange, together
+// with the reason, the notification ID if it is known and the number of delivery attempts made.
+// A failure to publish is only logged: the notification keeps its terminal status in storage.
+func (b *Broker) DeadLetter(body []byte, notificationID string, attempts int, reason error) {
+
+	pub := amqp091.Publishing{
+		ContentType:  contentType,
+		DeliveryMode: amqp091.Persistent,
+		Headers: amqp091.Table{
+			reasonHeader:         reason.Error(),
+			notificationIDHeader: notificationID,
+			attemptsHeader:       int64(attempts),
+		},
+		Body: body,
+	}
+
+	if err := retry.DoContext(b.client.Context(), retry.Strategy{
+		Attempts: b.config.Producer.Attempts,
+		Delay:    b.config.Producer.Delay,
+		Backoff:  b.config.Producer.Backoff}, func() error {
+		return b.publish(deadLetterExchange, b.deadLetterQueue(), true, pub)
+	}); err != nil {
+		b.logger.LogError("consumer — failed to publish dead letter", err,
+			"notificationID", notificationID, "reason", reason.Error(), "layer", "broker.rabbitMQ")
+	}
+
+}
+
+// recordDeadLetter processes a single message from the dead-letter queue by saving it in storage,
+// where it can be inspected and replayed.
+func (b *Broker) recordDeadLetter(ctx context.Context, msg amqp091.Delivery) error {
+
+	reason, _ := msg.Headers[reasonHeader].(string)
+	notificationID, _ := msg.Headers[notificationIDHeader].(string)
+
+	deadLetter := models.DeadLetter{
+		ID:             helpers.CreateUUID(),
+		NotificationID: notificationID,
+		Reason:         reason,
+		Body:           string(msg.Body),
+		Attempts:       deliveryAttempts(msg, 0),
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := b.storage.CreateDeadLetter(ctx, deadLetter); err != nil {
+		b.logger.LogError("consumer — failed to save dead letter in db", err,
+			"notificationID", notificationID, "layer", "broker.rabbitMQ")
+		return err
+	}
+
+	return nil
+
+}
@@ -0,0 +1,164 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	DelayQueue    = "queue"    // a delay queue per notification, with the TTL of the notification's delay
+	DelayBuckets  = "buckets"  // shared delay queues with fixed TTLs that a message hops through until it is due
+	DelayExchange = "exchange" // the exchange of the rabbitmq_delayed_message_exchange plugin
+)
+
+const (
+	delayedExchange     = "delayedExchange"
+	delayedExchangeKind = "x-delayed-message"
+
+	// dueHeader is the AMQP header carrying the time a delayed message is due, in Unix milliseconds.
+	// A message that reaches its delivery queue before that time is delayed again.
+	dueHeader   = "x-due"
+	delayHeader = "x-delay" // per-message delay read by the delayed message exchange
+
+	defaultPrecision = time.Second
+	bucketCount      = 26                             // buckets from precision up to precision*2^25, about a year at 1s
+	maxExchangeDelay = (1<<32 - 1) * time.Millisecond // longest delay the delayed message exchange accepts
+)
+
+// declareDelayStrategy prepares what the configured delay strategy needs: the delayed message exchange,
+// bound to every delivery queue, for the exchange strategy. Delay queues are declared on first use.
+func (b *Broker) declareDelayStrategy() error {
+
+	switch b.config.Delay.Strategy {
+
+	case "", DelayQueue, DelayBuckets:
+		return nil
+
+	case DelayExchange:
+		err := b.client.DeclareExchange(delayedExchange, delayedExchangeKind, true, false, false,
+			amqp.Table{"x-delayed-type": exchangeKind})
+		if err != nil {
+			return fmt.Errorf("failed to declare delayed message exchange: %w", err)
+		}
+		for _, priority := range priorities {
+			queue := b.deliveryQueue(priority)
+			if err := b.client.DeclareQueue(queue, delayedExchange, queue, true, false, true, nil); err != nil {
+				return fmt.Errorf("failed to bind queue %s to delayed message exchange: %w", queue, err)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown delay strategy %q", b.config.Delay.Strategy)
+
+	}
+
+}
+
+// publishDue publishes a notification that becomes due at the given time with the buckets or exchange strategy.
+// The message carries the due time in the dueHeader, so that a message that arrives early, because its delay
+// was longer than a single bucket or than the exchange accepts, is delayed again by the consumer.
+func (b *Broker) publishDue(notification models.Notification, due time.Time) error {
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification to json: %w", err)
+	}
+
+	pub := amqp.Publishing{
+		ContentType:  contentType,
+		DeliveryMode: amqp.Persistent,
+		Headers:      amqp.Table{attemptsHeader: int64(notification.Attempts), dueHeader: due.UnixMilli()},
+		Body:         body,
+	}
+
+	target := b.deliveryQueue(notification.Priority)
+	delay := time.Until(due)
+
+	if delay <= 0 {
+		return b.publish(mainExchange, target, true, pub)
+	}
+
+	if b.config.Delay.Strategy == DelayExchange {
+		pub.Headers[delayHeader] = min(delay, maxExchangeDelay).Milliseconds()
+		// the plugin routes a message only once its delay has passed, so it would return a mandatory one
+		return b.publish(delayedExchange, target, false, pub)
+	}
+
+	bucket, err := b.declareBucket(target, b.bucketTTL(delay))
+	if err != nil {
+		return err
+	}
+
+	if err := b.publish(mainExchange, bucket, true, pub); err != nil {
+		b.buckets.Delete(bucket) // declare it again on the next attempt, in case it has been deleted
+		return err
+	}
+
+	return nil
+
+}
+
+// bucketTTL returns the TTL of the bucket a message with the given remaining delay goes through next:
+// the longest bucket that does not overshoot the delay, or the shortest one for delays below it.
+// Bucket TTLs double from one to the next, so a message reaches its due time in at most bucketCount hops
+// and is delivered at most one precision late.
+func (b *Broker) bucketTTL(delay time.Duration) time.Duration {
+
+	precision := b.config.Delay.Precision
+	if precision <= 0 {
+		precision = defaultPrecision
+	}
+
+	ttl := precision
+	for range bucketCount - 1 {
+		if ttl*2 > delay {
+			break
+		}
+		ttl *= 2
+	}
+
+	return ttl
+
+}
+
+// declareBucket declares the bucket with the given TTL that dead-letters into the target delivery queue,
+// unless it has been declared already, and returns its name. Unlike per-notification queues, buckets are
+// shared by all notifications, so their number does not grow with the number of scheduled notifications.
+func (b *Broker) declareBucket(target string, ttl time.Duration) (string, error) {
+
+	bucket := fmt.Sprintf("%s.delay.%s", target, ttl)
+
+	if _, ok := b.buckets.Load(bucket); ok {
+		return bucket, nil
+	}
+
+	queueArgs := amqp.Table{
+		"x-message-ttl":             ttl.Milliseconds(),
+		"x-dead-letter-exchange":    mainExchange,
+		"x-dead-letter-routing-key": target,
+	}
+
+	if err := b.client.DeclareQueue(bucket, mainExchange, bucket, true, false, true, queueArgs); err != nil {
+		return "", fmt.Errorf("failed to declare delay bucket: %w", err)
+	}
+
+	b.buckets.Store(bucket, struct{}{})
+
+	return bucket, nil
+
+}
+
+// dueTime returns the time a delayed message is due, as carried in the dueHeader.
+// The second result is false for messages without the header, which are due as soon as they arrive.
+func dueTime(msg amqp.Delivery) (time.Time, bool) {
+	due, ok := msg.Headers[dueHeader].(int64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(due), true
+}
@@ -0,0 +1,51 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketTTL(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		precision time.Duration
+		delay     time.Duration
+		expected  time.Duration
+	}{
+		{"below precision rounds up", time.Second, 300 * time.Millisecond, time.Second},
+		{"exact bucket", time.Second, 8 * time.Second, 8 * time.Second},
+		{"longest bucket not overshooting", time.Second, 90 * time.Second, 64 * time.Second},
+		{"capped at the longest bucket", time.Second, 5 * 365 * 24 * time.Hour, time.Second << (bucketCount - 1)},
+		{"default precision", 0, 3 * time.Second, 2 * time.Second},
+		{"custom precision", 100 * time.Millisecond, time.Second, 800 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Broker{config: config.Broker{Delay: config.Delay{Strategy: DelayBuckets, Precision: tt.precision}}}
+			assert.Equal(t, tt.expected, b.bucketTTL(tt.delay))
+		})
+	}
+
+}
+
+func TestBucketTTL_Hops(t *testing.T) {
+
+	b := &Broker{config: config.Broker{Delay: config.Delay{Strategy: DelayBuckets, Precision: time.Second}}}
+
+	remaining := 100*24*time.Hour + 1234*time.Millisecond
+	var hops int
+
+	for remaining > 0 {
+		remaining -= b.bucketTTL(remaining)
+		hops++
+	}
+
+	assert.LessOrEqual(t, hops, bucketCount)
+	assert.Greater(t, remaining, -time.Second, "delivered more than one precision late")
+
+}
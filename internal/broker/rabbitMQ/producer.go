@@ -13,53 +13,159 @@ import (
 // Produce publishes a notification to RabbitMQ.
 // It schedules the message for future delivery according to notification.SendAt
 // and ensures reliable delivery using the configured retry strategy.
-// It creates a per-notification queue with TTL and dead-lettering to mainExchange.
-// If the queue already exists due to recovery, it skips re-declaring it.
+// With the default queue strategy it creates a per-notification queue with TTL and dead-lettering
+// to mainExchange, named after the notification's revision (see queueName), which routes the expired
+// message to the delivery queue of the notification's priority.
+// If the queue already holds the notification's message due to recovery, nothing is published (see existingQueue).
+// The buckets and exchange strategies delay the message without a queue of its own (see publishDue).
 func (b *Broker) Produce(notification models.Notification) error {
+	delay := max(time.Until(notification.SendAt), 0)
+	return b.publishDelayed(notification, queueName(notification.ID, notification.Revision), delay)
+}
+
+// publishDelayed publishes a notification that is due after delay with the configured delay strategy,
+// retrying with the producer retry strategy. The queue names the per-notification delay queue used by
+// the queue strategy; the other strategies share their delay queues between notifications.
+func (b *Broker) publishDelayed(notification models.Notification, queue string, delay time.Duration) error {
 
-	sendAt := max(time.Until(notification.SendAt), 0)
+	due := time.Now().Add(delay)
 
 	return retry.DoContext(b.client.Context(), retry.Strategy{
 		Attempts: b.config.Producer.Attempts,
 		Delay:    b.config.Producer.Delay,
 		Backoff:  b.config.Producer.Backoff}, func() error {
 
-		queueArgs := amqp.Table{
-			"x-message-ttl":             int64(sendAt.Milliseconds()),
-			"x-dead-letter-exchange":    mainExchange,
-			"x-dead-letter-routing-key": b.config.QueueName,
-			"x-expires":                 int64(sendAt.Milliseconds() + b.config.Producer.MessageQueueTTL.Milliseconds()),
+		switch b.config.Delay.Strategy {
+		case DelayBuckets, DelayExchange:
+			return b.publishDue(notification, due)
+		default:
+			return b.publishQueued(notification, queue, delay)
 		}
 
-		err := b.client.DeclareQueue(notification.ID, mainExchange, notification.ID, false, true, true, queueArgs)
-		if err != nil {
-			if amqpErr, ok := err.(*amqp.Error); ok && amqpErr.Code == amqp.PreconditionFailed { // exception 406
-				b.logger.Debug("producer — recovered notification is already in queue, skipping",
-					"notificationID", notification.ID, "layer", "broker.rabbitMQ")
-				return nil
-			}
-			return fmt.Errorf("failed to declare queue: %w", err)
-		}
+	})
 
-		ch, err := b.client.GetChannel()
-		if err != nil {
-			return fmt.Errorf("failed to get channel: %w", err)
-		}
-		defer func() { _ = ch.Close() }()
+}
 
-		body, err := json.Marshal(notification)
-		if err != nil {
-			return fmt.Errorf("failed to marshal notification to json: %w", err)
-		}
+// publishQueued declares a per-notification delay queue that dead-letters its message into
+// the delivery queue of the notification's priority after delay, and publishes the notification
+// into it. The number of delivery attempts made so far travels in the attemptsHeader.
+// The queue is durable and the message persistent, and the publish waits for the broker's confirm,
+// so a nil error means the message survives a broker restart; the x-expires argument removes the
+// queue once it has been empty for MessageQueueTTL.
+func (b *Broker) publishQueued(notification models.Notification, queue string, delay time.Duration) error {
 
-		pub := amqp.Publishing{ContentType: contentType, Body: body}
+	queueArgs := amqp.Table{
+		"x-message-ttl":             int64(delay.Milliseconds()),
+		"x-dead-letter-exchange":    mainExchange,
+		"x-dead-letter-routing-key": b.deliveryQueue(notification.Priority),
+		"x-expires":                 int64(delay.Milliseconds() + b.config.Producer.MessageQueueTTL.Milliseconds()),
+	}
 
-		if err := ch.PublishWithContext(b.client.Context(), mainExchange, notification.ID, false, false, pub); err != nil {
-			return fmt.Errorf("failed to publish with context: %w", err)
+	err := b.client.DeclareQueue(queue, mainExchange, queue, true, false, true, queueArgs)
+	if err != nil {
+		if amqpErr, ok := err.(*amqp.Error); ok && amqpErr.Code == amqp.PreconditionFailed { // exception 406
+			return b.existingQueue(notification, queue, err)
 		}
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification to json: %w", err)
+	}
+
+	pub := amqp.Publishing{
+		ContentType:  contentType,
+		DeliveryMode: amqp.Persistent,
+		Headers:      amqp.Table{attemptsHeader: int64(notification.Attempts)},
+		Body:         body,
+	}
 
+	return b.publish(mainExchange, queue, true, pub)
+
+}
+
+// existingQueue handles a delay queue that already exists with other arguments, such as one declared
+// for the same notification before a recovery re-queued it. If the queue still holds a message, the
+// notification is already waiting in it and nothing is published. Otherwise the empty queue, for example
+// one left behind by a previous version with other durability, is deleted and declareErr is returned,
+// so that the retry strategy declares the queue afresh and publishes the notification.
+func (b *Broker) existingQueue(notification models.Notification, queue string, declareErr error) error {
+
+	ch, err := b.client.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+	defer func() { _ = ch.Close() }()
+
+	existing, err := ch.QueueDeclarePassive(queue, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to inspect existing queue: %w", err)
+	}
+
+	if existing.Messages > 0 {
+		b.logger.Debug("producer — recovered notification is already in queue, skipping",
+			"notificationID", notification.ID, "layer", "broker.rabbitMQ")
 		return nil
+	}
 
-	})
+	if _, err := ch.QueueDelete(queue, false, true, false); err != nil {
+		return fmt.Errorf("failed to delete empty queue declared with other arguments: %w", err)
+	}
+
+	return fmt.Errorf("failed to declare queue: %w", declareErr)
+
+}
+
+// Reschedule deletes the per-notification queue of the previous revision of a rescheduled
+// notification, together with the message waiting in it, and produces the new revision.
+// A failure to delete the old queue is only logged: a message that still reaches the
+// consumer from it carries an outdated revision and is discarded there, as are the messages
+// of the buckets and exchange strategies, which cannot be removed from their shared queues.
+func (b *Broker) Reschedule(notification models.Notification) error {
+
+	if strategy := b.config.Delay.Strategy; strategy == DelayBuckets || strategy == DelayExchange {
+		return b.Produce(notification)
+	}
+
+	previous := queueName(notification.ID, notification.Revision-1)
+
+	if err := b.deleteQueue(previous); err != nil {
+		b.logger.LogError("producer — failed to delete queue of rescheduled notification", err,
+			"notificationID", notification.ID, "queue", previous, "layer", "broker.rabbitMQ")
+	}
+
+	return b.Produce(notification)
+
+}
+
+// deleteQueue deletes a queue regardless of its messages and consumers.
+// A queue that no longer exists is not an error.
+func (b *Broker) deleteQueue(queue string) error {
+
+	ch, err := b.client.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+	defer func() { _ = ch.Close() }()
+
+	if _, err := ch.QueueDelete(queue, false, false, false); err != nil {
+		if amqpErr, ok := err.(*amqp.Error); ok && amqpErr.Code == amqp.NotFound { // exception 404
+			return nil
+		}
+		return fmt.Errorf("failed to delete queue: %w", err)
+	}
+
+	return nil
+
+}
 
+// queueName returns the name of the per-notification queue of the given revision.
+// Every revision gets its own queue because queue arguments such as the TTL cannot be
+// changed once the queue is declared; the original revision keeps the plain notification ID.
+func queueName(notificationID string, revision int) string {
+	if revision == 0 {
+		return notificationID
+	}
+	return fmt.Sprintf("%s.r%d", notificationID, revision)
 }
@@ -0,0 +1,64 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const defaultConfirmTimeout = 5 * time.Second
+
+// publish publishes a message on a channel in confirm mode and waits until the broker confirms it.
+// A mandatory message that no queue is bound for is returned by the broker instead of being dropped.
+// A nack, a returned message or a confirmation that does not arrive within broker.producer.confirm_timeout
+// is reported as an error, which lets the caller's retry strategy publish the message again.
+func (b *Broker) publish(exchange string, key string, mandatory bool, pub amqp.Publishing) error {
+
+	ch, err := b.client.GetChannel()
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+	defer func() { _ = ch.Close() }()
+
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to put channel into confirm mode: %w", err)
+	}
+
+	returns := ch.NotifyReturn(make(chan amqp.Return, 1))
+
+	timeout := b.config.Producer.ConfirmTimeout
+	if timeout <= 0 {
+		timeout = defaultConfirmTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(b.client.Context(), timeout)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, false, pub)
+	if err != nil {
+		return fmt.Errorf("failed to publish with context: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for publisher confirm: %w", err)
+	}
+
+	// the broker sends basic.return before the confirmation of the same message,
+	// so a returned message is already in the buffered channel at this point
+	select {
+	case returned := <-returns:
+		return fmt.Errorf("message was returned by the broker: %d %s", returned.ReplyCode, returned.ReplyText)
+	default:
+	}
+
+	if !acked {
+		return errors.New("message was nacked by the broker")
+	}
+
+	return nil
+
+}
@@ -0,0 +1,91 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+)
+
+const defaultRelayInterval = 200 * time.Millisecond
+
+// relay publishes notifications from the outbox until ctx is canceled. Notifications are stored
+// together with their outbox entry in one transaction, so every stored notification is eventually
+// published, even if the broker is unavailable at the time it is created.
+func (b *Broker) relay(ctx context.Context) {
+
+	interval := b.config.RelayInterval
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		b.relayOutbox(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+	}
+
+}
+
+// relayOutbox claims unpublished outbox entries, produces their notifications and marks the entries as published.
+// Notifications that have been canceled or processed meanwhile are marked without being produced.
+// Relaying stops at the first notification that cannot be produced, so that the entries are published
+// in order once the broker is back; the entries left unpublished are claimed again once their claim has
+// expired. Nothing is attempted while the broker is unhealthy.
+func (b *Broker) relayOutbox(ctx context.Context) {
+
+	if !b.client.Healthy() {
+		return
+	}
+
+	entries, err := b.storage.ClaimOutbox(ctx, b.config.RelayClaimTimeout)
+	if err != nil {
+		b.logger.LogError("relay — failed to get outbox from db", err, "layer", "broker.rabbitMQ")
+		return
+	}
+
+	published := make([]int64, 0, len(entries))
+
+	for _, entry := range entries {
+
+		notification := entry.Notification
+
+		if notification.Status == models.StatusPending || notification.Status == models.StatusLate {
+			if err := b.produce(notification); err != nil {
+				b.logger.LogError("relay — failed to produce notification", err,
+					"notificationID", notification.ID, "layer", "broker.rabbitMQ")
+				break
+			}
+		}
+
+		published = append(published, entry.ID)
+
+	}
+
+	if len(published) == 0 {
+		return
+	}
+
+	if err := b.storage.MarkPublished(ctx, published); err != nil {
+		b.logger.LogError("relay — failed to mark outbox entries as published", err,
+			"count", len(published), "layer", "broker.rabbitMQ")
+	}
+
+}
+
+// produce publishes the notification of an outbox entry. Entries of rescheduled and replayed notifications
+// carry a later revision, whose previous per-notification queue is deleted together with its message.
+func (b *Broker) produce(notification models.Notification) error {
+	if notification.Revision > 0 {
+		return b.Reschedule(notification)
+	}
+	return b.Produce(notification)
+}
@@ -0,0 +1,37 @@
+package rabbitmq
+
+import (
+	"Chronos/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// attemptsHeader is the AMQP header carrying the number of delivery attempts made before the message was published.
+const attemptsHeader = "x-attempts"
+
+// Retry schedules another delivery attempt of a notification that failed to be delivered.
+// The notification goes back through a per-attempt delay queue that dead-letters it into its
+// delivery queue once delay has passed.
+func (b *Broker) Retry(notification models.Notification, delay time.Duration) error {
+	queue := fmt.Sprintf("%s.a%d", queueName(notification.ID, notification.Revision), notification.Attempts)
+	return b.publishDelayed(notification, queue, delay)
+}
+
+// deliveryAttempts returns the number of delivery attempts made before the message was published,
+// as carried in the attemptsHeader. Messages without the header fall back to the notification's own counter.
+func deliveryAttempts(msg amqp091.Delivery, fallback int) int {
+
+	switch attempts := msg.Headers[attemptsHeader].(type) {
+	case int64:
+		return int(attempts)
+	case int32:
+		return int(attempts)
+	case int:
+		return attempts
+	}
+
+	return fallback
+
+}
@@ -10,6 +10,11 @@ import (
 	wbf "github.com/wb-go/wbf/config"
 )
 
+const (
+	defaultIdempotencyTTL    = 24 * time.Hour   // used when the retention of idempotency keys is not configured
+	defaultRelayClaimTimeout = 30 * time.Second // used when the outbox claim timeout is not configured
+)
+
 // Config is the top-level application configuration, containing logger, notifier, server, storage, broker, and cache settings.
 type Config struct {
 	Logger   Logger   `mapstructure:"logger"`   // logger configuration
@@ -20,14 +25,30 @@ type Config struct {
 	Cache    Cache    `mapstructure:"cache"`    // cache configuration
 }
 
-// Notifier contains credentials and settings for Telegram and Email notifications.
+// Notifier contains the enabled delivery channels and credentials for Telegram, Email and Webhook notifications.
 type Notifier struct {
-	TelegramToken    string // Telegram bot token
-	TelegramReceiver string // Telegram chat ID
-	EmailSender      string // email sender address
-	EmailPassword    string // email password
-	EmailSMTP        string // SMTP server username/password if needed
-	EmailSMTPAddr    string // SMTP server address
+	Channels               []string                 `mapstructure:"channels"`        // built-in channels registered at boot
+	DefaultTimeout         time.Duration            `mapstructure:"default_timeout"` // send timeout for channels without their own timeout
+	Timeouts               map[string]time.Duration `mapstructure:"timeouts"`        // per-channel send timeouts keyed by channel name
+	TelegramToken          string                   // Telegram bot token
+	TelegramReceiver       string                   // Telegram chat ID
+	EmailSender            string                   // email sender address
+	EmailPassword          string                   // email password
+	EmailSMTP              string                   // SMTP server username/password if needed
+	EmailSMTPAddr          string                   // SMTP server address
+	WebhookSecret          string                   // HMAC secret used to sign webhook payloads
+	WebhookSignatureHeader string                   `mapstructure:"webhook_signature_header"` // header carrying the webhook payload signature
+	SMTP                   SMTP                     `mapstructure:"smtp"`                     // SMTP transport settings of the email channel
+}
+
+// SMTP defines how the email channel connects and authenticates to the SMTP server.
+type SMTP struct {
+	TLS         string        `mapstructure:"tls"`          // "implicit" (SMTPS, port 465), "starttls" (required), "opportunistic" (default) or "none"
+	Auth        string        `mapstructure:"auth"`         // authentication mechanism: "plain" (default), "login", "cram-md5" or "none"
+	CAFile      string        `mapstructure:"ca_file"`      // PEM file with additional trusted CA certificates
+	PoolSize    int           `mapstructure:"pool_size"`    // maximum number of idle connections kept for reuse
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"` // idle connections older than this are closed instead of reused
+	DialTimeout time.Duration `mapstructure:"dial_timeout"` // timeout for establishing a new connection
 }
 
 // Logger defines logging configuration.
@@ -47,15 +68,34 @@ type Server struct {
 
 // Broker contains configuration for the message broker.
 type Broker struct {
+	Backend             string        `mapstructure:"backend"`              // broker backend: rabbitmq (default), postgres or memory
 	URL                 string        `mapstructure:"url"`                  // broker connection URL
-	QueueName           string        `mapstructure:"queue_name"`           // main queue name
+	QueueName           string        `mapstructure:"queue_name"`           // main queue name, used by normal priority notifications
 	ConnectionName      string        `mapstructure:"connection_name"`      // connection name
 	ConnectTimeout      time.Duration `mapstructure:"connect_timeout"`      // timeout for initial connection
 	Reconnect           Producer      `mapstructure:"reconnect"`            // reconnect retry strategy
 	Producer            Producer      `mapstructure:"producer"`             // producer retry strategy
 	Consumer            Consumer      `mapstructure:"consumer"`             // consumer retry strategy
+	Retry               Retry         `mapstructure:"retry"`                // delivery retry strategy; attempts include the first one
 	CleanupInterval     time.Duration `mapstructure:"cleanup_interval"`     // interval for cleanup tasks
 	HealthcheckInterval time.Duration `mapstructure:"healthcheck_interval"` // interval for health checks
+	RelayInterval       time.Duration `mapstructure:"relay_interval"`       // interval at which the outbox is published
+	RelayClaimTimeout   time.Duration `mapstructure:"relay_claim_timeout"`  // how long claimed outbox entries stay reserved for the relay publishing them
+	Poller              Poller        `mapstructure:"poller"`               // polling settings of the postgres backend
+	Delay               Delay         `mapstructure:"delay"`                // how messages wait for their send time in RabbitMQ
+}
+
+// Delay defines how the rabbitmq broker backend delays messages until they are due.
+type Delay struct {
+	Strategy  string        `mapstructure:"strategy"`  // queue (default), buckets or exchange
+	Precision time.Duration `mapstructure:"precision"` // TTL of the shortest delay bucket, buckets strategy only
+}
+
+// Poller defines how the postgres broker backend polls for due notifications.
+type Poller struct {
+	Interval  time.Duration `mapstructure:"interval"`   // interval between polls when no notifications are due
+	BatchSize int           `mapstructure:"batch_size"` // max number of notifications claimed per poll
+	Workers   int           `mapstructure:"workers"`    // number of concurrent workers sending notifications
 }
 
 // Producer defines retry and message queue settings for producer operations.
@@ -64,17 +104,35 @@ type Producer struct {
 	Delay           time.Duration `mapstructure:"delay"`             // delay between retries
 	Backoff         float64       `mapstructure:"backoff"`           // backoff multiplier
 	MessageQueueTTL time.Duration `mapstructure:"message_queue_ttl"` // queue TTL for messages
+	ConfirmTimeout  time.Duration `mapstructure:"confirm_timeout"`   // how long to wait for a publisher confirm
+}
+
+// Retry defines how failed deliveries are retried: the delay before the first retry is multiplied
+// by the backoff for every further one, up to the optional maximum delay.
+type Retry struct {
+	Attempts int           `mapstructure:"attempts"`  // delivery attempts per notification, including the first one
+	Delay    time.Duration `mapstructure:"delay"`     // delay before the first retry
+	Backoff  float64       `mapstructure:"backoff"`   // backoff multiplier for every further retry
+	MaxDelay time.Duration `mapstructure:"max_delay"` // upper bound of the delay between retries, 0 for none
 }
 
 // Consumer defines retry and consumption settings for consumer operations.
 type Consumer struct {
-	Attempts      int           `mapstructure:"attempts"`       // number of retry attempts
-	Delay         time.Duration `mapstructure:"delay"`          // delay between retries
-	Backoff       float64       `mapstructure:"backoff"`        // backoff multiplier
-	ConsumerTag   string        `mapstructure:"consumer_tag"`   // consumer tag
-	AutoAck       bool          `mapstructure:"auto_ack"`       // auto-acknowledge messages
-	Workers       int           `mapstructure:"workers"`        // number of worker goroutines
-	PrefetchCount int           `mapstructure:"prefetch_count"` // prefetch count for QoS
+	Attempts      int              `mapstructure:"attempts"`       // number of retry attempts
+	Delay         time.Duration    `mapstructure:"delay"`          // delay between retries
+	Backoff       float64          `mapstructure:"backoff"`        // backoff multiplier
+	ConsumerTag   string           `mapstructure:"consumer_tag"`   // consumer tag
+	ClaimTimeout  time.Duration    `mapstructure:"claim_timeout"`  // how long a claimed notification stays reserved for the consumer sending it
+	Workers       int              `mapstructure:"workers"`        // number of worker goroutines per priority queue
+	PrefetchCount int              `mapstructure:"prefetch_count"` // prefetch count for QoS per priority queue
+	MaxDelay      time.Duration    `mapstructure:"max_delay"`      // default delivery deadline after send_at, 0 to never expire
+	Queues        map[string]Queue `mapstructure:"queues"`         // per-priority overrides of workers and prefetch count
+}
+
+// Queue defines the consumption settings of the delivery queue of a single priority.
+type Queue struct {
+	Workers       int `mapstructure:"workers"`        // number of worker goroutines
+	PrefetchCount int `mapstructure:"prefetch_count"` // prefetch count for QoS
 }
 
 // Storage defines database connection and query retry configuration.
@@ -93,11 +151,12 @@ type Storage struct {
 	RetentionStrategy  Retention     `mapstructure:"retention_strategy"`   // retention durations
 }
 
-// Retention specifies retention periods for notifications by status.
+// Retention specifies retention periods for notifications by status and for idempotency keys.
 type Retention struct {
-	Canceled  time.Duration // retention for canceled notifications
-	Completed time.Duration // retention for completed notifications
-	Failed    time.Duration // retention for failed notifications
+	Canceled    time.Duration // retention for canceled notifications
+	Completed   time.Duration // retention for completed notifications
+	Failed      time.Duration // retention for failed notifications
+	Idempotency time.Duration // how long an idempotency key maps to the notification it created
 }
 
 // Cache defines Redis cache connection and retry configuration.
@@ -132,6 +191,14 @@ func Load() (Config, error) {
 
 	loadEnvs(&conf)
 
+	if conf.Storage.RetentionStrategy.Idempotency <= 0 {
+		conf.Storage.RetentionStrategy.Idempotency = defaultIdempotencyTTL
+	}
+
+	if conf.Broker.RelayClaimTimeout <= 0 {
+		conf.Broker.RelayClaimTimeout = defaultRelayClaimTimeout
+	}
+
 	return conf, nil
 
 }
@@ -152,4 +219,6 @@ func loadEnvs(conf *Config) {
 	conf.Notifier.EmailSMTP = os.Getenv("GOOGLE_APP_SMTP")
 	conf.Notifier.EmailSMTPAddr = os.Getenv("SMTP_ADDR")
 
+	conf.Notifier.WebhookSecret = os.Getenv("WEBHOOK_SECRET")
+
 }
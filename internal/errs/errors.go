@@ -5,23 +5,55 @@ package errs
 import "errors"
 
 var (
-	ErrInvalidJSON           = errors.New("invalid JSON format")                                                                      // invalid JSON format
-	ErrInvalidNotificationID = errors.New("missing or invalid notification ID")                                                       // invalid notification ID
-	ErrMissingChannel        = errors.New("channel is required")                                                                      // channel is required
-	ErrUnsupportedChannel    = errors.New("unsupported channel")                                                                      // unsupported channel
-	ErrMessageTooLong        = errors.New("message exceeds maximum length")                                                           // message exceeds maximum length
-	ErrMissingSendAt         = errors.New("send_at is required")                                                                      // send_at is required
-	ErrInvalidSendAt         = errors.New("invalid send_at format, expected RFC3339")                                                 // invalid send_at format, expected RFC3339
-	ErrSendAtInPast          = errors.New("send_at cannot be in the past")                                                            // send_at cannot be in the past
-	ErrSendAtTooFar          = errors.New("send_at is too far in the future")                                                         // send_at is too far in the future
-	ErrMissingSendTo         = errors.New("send_to is required")                                                                      // send_to is required
-	ErrInvalidEmailFormat    = errors.New("invalid email format")                                                                     // invalid email format
-	ErrMissingEmailSubject   = errors.New("email subject is required")                                                                // email subject is required
-	ErrEmailSubjectTooLong   = errors.New("email subject is too long")                                                                // email subject is too long
-	ErrRecipientTooLong      = errors.New("recipient exceeds maximum length")                                                         // recipient exceeds maximum length
-	ErrNotificationNotFound  = errors.New("notification with given ID not found")                                                     // notification with given ID not found
-	ErrAlreadyCanceled       = errors.New("notification is already canceled")                                                         // notification is already canceled
-	ErrCannotCancel          = errors.New("notification cannot be canceled in its current state")                                     // notification cannot be canceled in its current state
-	ErrInternal              = errors.New("internal server error")                                                                    // internal server error
-	ErrUrgentDeliveryFailed  = errors.New("cannot schedule notification for immediate delivery — service is temporarily unavailable") // cannot schedule notification for immediate delivery — service is temporarily unavailable
+	ErrInvalidJSON           = errors.New("invalid JSON format")                                                              // invalid JSON format
+	ErrInvalidNotificationID = errors.New("missing or invalid notification ID")                                               // invalid notification ID
+	ErrMissingChannel        = errors.New("channel is required")                                                              // channel is required
+	ErrUnsupportedChannel    = errors.New("unsupported channel")                                                              // unsupported channel
+	ErrMessageTooLong        = errors.New("message exceeds maximum length")                                                   // message exceeds maximum length
+	ErrMissingSendAt         = errors.New("send_at or send_in is required")                                                   // send_at or send_in is required
+	ErrInvalidSendAt         = errors.New("invalid send_at format, expected RFC3339 or a local time with time_zone")          // invalid send_at format, expected RFC3339 or a local time with time_zone
+	ErrInvalidSendIn         = errors.New("invalid send_in, expected positive duration such as 15m or 2h30m")                 // invalid send_in, expected positive duration such as 15m or 2h30m
+	ErrSendAtWithSendIn      = errors.New("send_at and send_in are mutually exclusive")                                       // send_at and send_in are mutually exclusive
+	ErrSendAtInPast          = errors.New("send_at cannot be in the past")                                                    // send_at cannot be in the past
+	ErrSendAtTooFar          = errors.New("send_at is too far in the future")                                                 // send_at is too far in the future
+	ErrMissingSendTo         = errors.New("send_to is required")                                                              // send_to is required
+	ErrInvalidEmailFormat    = errors.New("invalid email format")                                                             // invalid email format
+	ErrMissingEmailSubject   = errors.New("email subject is required")                                                        // email subject is required
+	ErrEmailSubjectTooLong   = errors.New("email subject is too long")                                                        // email subject is too long
+	ErrRecipientTooLong      = errors.New("recipient exceeds maximum length")                                                 // recipient exceeds maximum length
+	ErrInvalidTelegramChat   = errors.New("invalid telegram chat, expected chat ID or @username with optional /thread ID")    // invalid telegram chat, expected chat ID or @username with optional /thread ID
+	ErrInvalidWebhookURL     = errors.New("invalid webhook URL, expected absolute http or https URL")                         // invalid webhook URL, expected absolute http or https URL
+	ErrTooManyRecipients     = errors.New("too many recipients for this channel")                                             // too many recipients for this channel
+	ErrInvalidTemplateName   = errors.New("template name must be 1 to 64 letters, digits, dashes or underscores")             // template name must be 1 to 64 letters, digits, dashes or underscores
+	ErrInvalidTemplate       = errors.New("invalid template")                                                                 // invalid template
+	ErrUnknownTemplate       = errors.New("unknown template")                                                                 // unknown template
+	ErrTemplateWithMessage   = errors.New("template and message are mutually exclusive")                                      // template and message are mutually exclusive
+	ErrTemplateRender        = errors.New("failed to render template")                                                        // failed to render template
+	ErrInvalidRecurrence     = errors.New("invalid recurrence, expected either a cron expression or an RRULE")                // invalid recurrence, expected either a cron expression or an RRULE
+	ErrInvalidTimeZone       = errors.New("invalid time zone, expected IANA name")                                            // invalid time zone, expected IANA name
+	ErrInvalidScheduleID     = errors.New("missing or invalid schedule ID")                                                   // missing or invalid schedule ID
+	ErrInvalidScheduleTime   = errors.New("invalid start_at or end_at format, expected RFC3339")                              // invalid start_at or end_at format, expected RFC3339
+	ErrInvalidScheduleBounds = errors.New("invalid schedule bounds, check end_at and max_occurrences")                        // invalid schedule bounds, check end_at and max_occurrences
+	ErrNoOccurrences         = errors.New("schedule has no upcoming occurrences")                                             // schedule has no upcoming occurrences
+	ErrInvalidIdempotencyKey = errors.New("invalid Idempotency-Key, expected 1 to 255 printable ASCII characters")            // invalid Idempotency-Key, expected 1 to 255 printable ASCII characters
+	ErrInvalidBatchSize      = errors.New("batch must contain 1 to 1000 notifications")                                       // batch must contain 1 to 1000 notifications
+	ErrInvalidExpiry         = errors.New("invalid expiry, expected either expires_at after send_at or a positive max_delay") // invalid expiry, expected either expires_at after send_at or a positive max_delay
+	ErrInvalidPriority       = errors.New("invalid priority, expected high, normal or bulk")                                  // invalid priority, expected high, normal or bulk
+	ErrInvalidTag            = errors.New("invalid tag, expected at most 16 tags of 1 to 64 characters without spaces")       // invalid tag, expected at most 16 tags of 1 to 64 characters without spaces
+	ErrInvalidDeadLetterID   = errors.New("missing or invalid dead letter ID")                                                // missing or invalid dead letter ID
+	ErrCannotReplay          = errors.New("dead letter cannot be replayed")                                                   // dead letter cannot be replayed
+	ErrNotificationNotFound  = errors.New("notification with given ID not found")                                             // notification with given ID not found
+	ErrTemplateNotFound      = errors.New("template with given name not found")                                               // template with given name not found
+	ErrTemplateExists        = errors.New("template with given name already exists")                                          // template with given name already exists
+	ErrTemplateInUse         = errors.New("template is used by scheduled notifications")                                      // template is used by scheduled notifications
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")                        // idempotency key was already used with a different request
+	ErrUnknownIdempotencyKey = errors.New("unknown idempotency key")                                                          // unknown idempotency key
+	ErrDeadLetterNotFound    = errors.New("dead letter with given ID not found")                                              // dead letter with given ID not found
+	ErrScheduleNotFound      = errors.New("schedule with given ID not found")                                                 // schedule with given ID not found
+	ErrScheduleNotActive     = errors.New("schedule is not active")                                                           // schedule is not active
+	ErrAlreadyCanceled       = errors.New("notification is already canceled")                                                 // notification is already canceled
+	ErrCannotCancel          = errors.New("notification cannot be canceled in its current state")                             // notification cannot be canceled in its current state
+	ErrCannotReschedule      = errors.New("notification cannot be rescheduled in its current state")                          // notification cannot be rescheduled in its current state
+	ErrNotificationClaimed   = errors.New("notification is already being sent")                                               // notification is already being sent
+	ErrInternal              = errors.New("internal server error")                                                            // internal server error
 )
@@ -16,7 +16,7 @@ import (
 const templatePath = "web/templates/index.html"
 
 // NewHandler creates and returns an http.Handler configured with all routes, middleware, and template rendering.
-// It includes API v1 routes for notifications and a web frontend at the root path.
+// It includes API v1 routes for notifications, recurring schedules and message templates and a web frontend at the root path.
 func NewHandler(service service.Service) http.Handler {
 
 	handler := ginext.New("")
@@ -29,8 +29,24 @@ func NewHandler(service service.Service) http.Handler {
 
 	apiV1.GET("/notify", handlerV1.GetNotification)
 	apiV1.POST("/notify", handlerV1.CreateNotification)
+	apiV1.POST("/notify/batch", handlerV1.CreateBatch)
+	apiV1.GET("/notify/tag", handlerV1.ListByTag)
+	apiV1.DELETE("/notify/tag", handlerV1.CancelByTag)
+	apiV1.PATCH("/notify", handlerV1.RescheduleNotification)
 	apiV1.DELETE("/notify", handlerV1.CancelNotification)
 
+	apiV1.GET("/schedules", handlerV1.GetSchedule)
+	apiV1.POST("/schedules", handlerV1.CreateSchedule)
+	apiV1.DELETE("/schedules", handlerV1.CancelSchedule)
+
+	apiV1.GET("/templates", handlerV1.GetTemplates)
+	apiV1.POST("/templates", handlerV1.CreateTemplate)
+	apiV1.PUT("/templates", handlerV1.UpdateTemplate)
+	apiV1.DELETE("/templates", handlerV1.DeleteTemplate)
+
+	apiV1.GET("/admin/dead-letters", handlerV1.GetDeadLetters)
+	apiV1.POST("/admin/dead-letters/replay", handlerV1.ReplayDeadLetters)
+
 	handler.GET("/", homePage(template.Must(template.ParseFiles(templatePath)), service))
 
 	return handler
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+
+	"github.com/wb-go/wbf/ginext"
+)
+
+// CreateBatch handles POST /notify/batch requests.
+// It parses every notification of the batch and creates the valid ones via the service in one go.
+// The response holds one result per notification, in request order: the created ID or the error
+// that prevented its creation. Only errors concerning the batch as a whole, such as invalid JSON,
+// an invalid batch size or a storage failure, are returned as an error response.
+func (h *Handler) CreateBatch(c *ginext.Context) {
+
+	var request CreateBatchV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	if len(request.Notifications) == 0 || len(request.Notifications) > models.MaxBatchSize {
+		respondError(c, errs.ErrInvalidBatchSize)
+		return
+	}
+
+	results := make([]BatchResultV1, len(request.Notifications))
+	notifications := make([]models.Notification, 0, len(request.Notifications))
+	positions := make([]int, 0, len(request.Notifications))
+
+	for i, item := range request.Notifications {
+		notification, err := toNotification(item)
+		if err != nil {
+			results[i] = toBatchResult(models.BatchResult{Err: err})
+			continue
+		}
+		notifications = append(notifications, notification)
+		positions = append(positions, i)
+	}
+
+	if len(notifications) > 0 {
+
+		created, err := h.service.CreateBatch(c.Request.Context(), notifications)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		for j, result := range created {
+			results[positions[j]] = toBatchResult(result)
+		}
+
+	}
+
+	respondOK(c, results)
+
+}
+
+// toBatchResult converts the outcome of a batch item into its response, exposing errors
+// the same way error responses do.
+func toBatchResult(result models.BatchResult) BatchResultV1 {
+	if result.Err != nil {
+		_, msg := mapErrorToStatus(result.Err)
+		return BatchResultV1{Error: msg}
+	}
+	return BatchResultV1{ID: result.ID}
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/helpers"
+)
+
+// GetDeadLetters handles GET /admin/dead-letters and GET /admin/dead-letters?id=<id> requests.
+// Without an ID the dead letters that have not been replayed yet are returned together with their
+// failure reasons, otherwise only the given one, including the raw message body.
+func (h *Handler) GetDeadLetters(c *ginext.Context) {
+
+	deadLetterID := c.Query("id")
+
+	if deadLetterID == "" {
+		deadLetters, err := h.service.ListDeadLetters(c.Request.Context())
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if deadLetters == nil {
+			deadLetters = []models.DeadLetter{}
+		}
+		respondOK(c, deadLetters)
+		return
+	}
+
+	if err := helpers.ParseUUID(deadLetterID); err != nil {
+		respondError(c, errs.ErrInvalidDeadLetterID)
+		return
+	}
+
+	deadLetter, err := h.service.GetDeadLetter(c.Request.Context(), deadLetterID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, deadLetter)
+
+}
+
+// ReplayDeadLetters handles POST /admin/dead-letters/replay requests.
+// It sends the notifications of the selected dead letters again. The response holds one result
+// per dead letter, in request order: its ID or the error that prevented the replay.
+func (h *Handler) ReplayDeadLetters(c *ginext.Context) {
+
+	var request ReplayDeadLettersV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	replayed, err := h.service.ReplayDeadLetters(c.Request.Context(), request.IDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	results := make([]BatchResultV1, len(replayed))
+	for i, result := range replayed {
+		results[i] = toBatchResult(result)
+	}
+
+	respondOK(c, results)
+
+}
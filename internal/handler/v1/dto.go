@@ -3,9 +3,78 @@ package v1
 // CreateNotificationV1 represents the JSON payload for creating a new notification via the v1 API.
 // It is used in POST /notify requests.
 type CreateNotificationV1 struct {
-	Channel string   `json:"channel"` // The channel to send the notification through (e.g., "email", "telegram").
-	Subject string   `json:"subject"` // The subject or title of the notification (used for email, optional for other channels).
-	Message string   `json:"message"` // The main content of the notification.
-	SendAt  string   `json:"send_at"` // The scheduled send time in RFC3339 format.
-	SendTo  []string `json:"send_to"` // The list of recipients for the notification.
+	Channel     string         `json:"channel"`      // The channel to send the notification through (e.g., "email", "telegram").
+	Subject     string         `json:"subject"`      // The subject or title of the notification (used for email, optional for other channels).
+	Message     string         `json:"message"`      // The main content of the notification.
+	HTMLMessage string         `json:"html_message"` // The HTML alternative of the message (email only).
+	SendAt      string         `json:"send_at"`      // The scheduled send time in RFC3339 format, or a local time in time_zone.
+	SendIn      string         `json:"send_in"`      // The delay before sending, such as "15m" or "2h30m", instead of send_at.
+	TimeZone    string         `json:"time_zone"`    // The caller's IANA time zone, such as "Europe/Moscow"; send_at_local is rendered in it.
+	ExpiresAt   string         `json:"expires_at"`   // The delivery deadline in RFC3339 format; the notification expires instead of being sent after it.
+	MaxDelay    string         `json:"max_delay"`    // The delivery deadline relative to the send time, such as "15m", instead of expires_at.
+	SendTo      []string       `json:"send_to"`      // The list of recipients for the notification.
+	CC          []string       `json:"cc"`           // The list of carbon copy recipients (email only).
+	BCC         []string       `json:"bcc"`          // The list of blind carbon copy recipients (email only).
+	ReplyTo     string         `json:"reply_to"`     // The Reply-To address (email only).
+	Template    string         `json:"template"`     // The name of the template to render the message from, instead of message and html_message.
+	Params      map[string]any `json:"params"`       // The parameters the template is rendered with.
+	Tags        []string       `json:"tags"`         // The tags used to list and cancel related notifications, such as "order:123".
+	Priority    string         `json:"priority"`     // The delivery priority: "high", "normal" (default) or "bulk".
+}
+
+// CreateBatchV1 represents the JSON payload for creating several notifications at once via the v1 API.
+// It is used in POST /notify/batch requests.
+type CreateBatchV1 struct {
+	Notifications []CreateNotificationV1 `json:"notifications"` // The notifications to create, at most models.MaxBatchSize.
+}
+
+// BatchResultV1 is the result of a single notification of a batch.
+// Exactly one of its fields is set.
+type BatchResultV1 struct {
+	ID    string `json:"id,omitempty"`    // The ID of the created notification.
+	Error string `json:"error,omitempty"` // The reason the notification was not created.
+}
+
+// ReplayDeadLettersV1 represents the JSON payload for replaying dead letters via the v1 API.
+// It is used in POST /admin/dead-letters/replay requests.
+type ReplayDeadLettersV1 struct {
+	IDs []string `json:"ids"` // The IDs of the dead letters to replay, at most models.MaxBatchSize.
+}
+
+// RescheduleNotificationV1 represents the JSON payload for rescheduling a notification via the v1 API.
+// It is used in PATCH /notify requests.
+type RescheduleNotificationV1 struct {
+	SendAt   string  `json:"send_at"`   // The new scheduled send time in RFC3339 format, or a local time in time_zone.
+	SendIn   string  `json:"send_in"`   // The delay before sending, such as "15m" or "2h30m", instead of send_at.
+	TimeZone string  `json:"time_zone"` // The IANA time zone a send_at without an offset is given in, such as "Europe/Moscow".
+	Message  *string `json:"message"`   // The optional new content of the notification; the current one is kept if omitted.
+}
+
+// TemplateV1 represents the JSON payload for creating or updating a message template via the v1 API.
+// It is used in POST /templates and PUT /templates requests.
+type TemplateV1 struct {
+	Name     string                       `json:"name"`     // The unique template name (ignored on update, taken from the query).
+	Variants map[string]TemplateVariantV1 `json:"variants"` // The template variants keyed by channel name or "default".
+}
+
+// TemplateVariantV1 represents the wording of a template for a single channel.
+type TemplateVariantV1 struct {
+	Subject     string `json:"subject"`      // The subject template (text/template syntax).
+	Message     string `json:"message"`      // The plain-text message template (text/template syntax).
+	HTMLMessage string `json:"html_message"` // The HTML message template (html/template syntax, email only).
+}
+
+// CreateScheduleV1 represents the JSON payload for creating a recurring notification via the v1 API.
+// It is used in POST /schedules requests. Every occurrence is created from the embedded notification
+// fields; send_at and send_in are ignored and computed from the recurrence rule instead,
+// and the deadline of every occurrence is set with max_delay, as expires_at is not supported.
+// The embedded time_zone is the IANA time zone the rule is evaluated in (UTC by default)
+// and the one send_at_local of every occurrence is rendered in.
+type CreateScheduleV1 struct {
+	CreateNotificationV1
+	Cron           string `json:"cron"`            // A five-field cron expression, such as "0 9 * * MON-FRI".
+	RRule          string `json:"rrule"`           // An RFC 5545 recurrence rule, such as "FREQ=WEEKLY;BYDAY=MO".
+	StartAt        string `json:"start_at"`        // The optional start of the series in RFC3339 format, also the RRULE DTSTART.
+	EndAt          string `json:"end_at"`          // The optional end of the series in RFC3339 format.
+	MaxOccurrences int    `json:"max_occurrences"` // The optional maximum number of occurrences.
 }
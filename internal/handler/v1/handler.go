@@ -1,11 +1,12 @@
 // Package v1 provides version 1 of the Chronos API handlers for notifications.
-// It includes endpoints to create, query, and cancel notifications via HTTP.
+// It includes endpoints to create, query, reschedule, and cancel notifications via HTTP.
 package v1
 
 import (
 	"Chronos/internal/errs"
 	"Chronos/internal/models"
 	"Chronos/internal/service"
+	"net/http"
 
 	"github.com/wb-go/wbf/ginext"
 	"github.com/wb-go/wbf/helpers"
@@ -25,6 +26,7 @@ func NewHandler(service service.Service) *Handler {
 // CreateNotification handles POST /notify requests.
 // It parses the JSON body, validates the input, creates a new notification via the service,
 // and returns the generated notification ID. Errors are returned for invalid input or service failures.
+// With an Idempotency-Key header, a retried request returns the ID of the notification created the first time.
 func (h *Handler) CreateNotification(c *ginext.Context) {
 
 	var request CreateNotificationV1
@@ -34,21 +36,19 @@ func (h *Handler) CreateNotification(c *ginext.Context) {
 		return
 	}
 
-	sendAt, err := parseTime(request.SendAt)
+	notification, err := toNotification(request)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
 
-	notification := models.Notification{
-		Channel: request.Channel,
-		Subject: request.Subject,
-		Message: request.Message,
-		SendAt:  sendAt,
-		SendTo:  request.SendTo,
+	var id string
+	if key := c.GetHeader(idempotencyKeyHeader); key == "" {
+		id, err = h.service.CreateNotification(c.Request.Context(), notification)
+	} else {
+		id, err = h.service.CreateNotificationOnce(c.Request.Context(), notification,
+			models.IdempotencyKey{Key: key, RequestHash: requestHash(request)})
 	}
-
-	id, err := h.service.CreateNotification(c.Request.Context(), notification)
 	if err != nil {
 		respondError(c, err)
 		return
@@ -60,6 +60,7 @@ func (h *Handler) CreateNotification(c *ginext.Context) {
 
 // GetNotification handles GET /notify?id=<id> requests.
 // It validates the notification ID and returns the current status of the notification.
+// With recipients=true, the per-recipient delivery breakdown is returned as well.
 // Returns an error if the ID is invalid or the notification is not found.
 func (h *Handler) GetNotification(c *ginext.Context) {
 
@@ -75,7 +76,18 @@ func (h *Handler) GetNotification(c *ginext.Context) {
 		return
 	}
 
-	respondOK(c, status)
+	if c.Query("recipients") != "true" {
+		respondOK(c, status)
+		return
+	}
+
+	recipients, err := h.service.GetRecipients(c.Request.Context(), notificationID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ginext.H{"result": status, "recipients": recipients})
 
 }
 
@@ -98,3 +110,70 @@ func (h *Handler) CancelNotification(c *ginext.Context) {
 	respondOK(c, "canceled")
 
 }
+
+// RescheduleNotification handles PATCH /notify?id=<id> requests.
+// It validates the notification ID and the new send time, given like on creation, and moves
+// the notification, optionally replacing its message. The notification keeps its ID.
+// Returns an error if the input is invalid or the notification is no longer pending or running late.
+func (h *Handler) RescheduleNotification(c *ginext.Context) {
+
+	notificationID := c.Query("id")
+	if err := helpers.ParseUUID(notificationID); err != nil {
+		respondError(c, errs.ErrInvalidNotificationID)
+		return
+	}
+
+	var request RescheduleNotificationV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	sendAt, err := parseSendTime(request.SendAt, request.SendIn, request.TimeZone)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := h.service.RescheduleNotification(c.Request.Context(), notificationID, sendAt, request.Message); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, "rescheduled")
+
+}
+
+// toNotification converts a create request into a notification, resolving its send time.
+func toNotification(request CreateNotificationV1) (models.Notification, error) {
+
+	sendAt, err := parseSendTime(request.SendAt, request.SendIn, request.TimeZone)
+	if err != nil {
+		return models.Notification{}, err
+	}
+
+	expiresAt, err := parseExpiry(request.ExpiresAt, request.MaxDelay, sendAt)
+	if err != nil {
+		return models.Notification{}, err
+	}
+
+	return models.Notification{
+		Channel:     request.Channel,
+		Subject:     request.Subject,
+		Message:     request.Message,
+		HTMLMessage: request.HTMLMessage,
+		SendAt:      sendAt,
+		TimeZone:    request.TimeZone,
+		ExpiresAt:   expiresAt,
+		SendTo:      request.SendTo,
+		CC:          request.CC,
+		BCC:         request.BCC,
+		ReplyTo:     request.ReplyTo,
+		Template:    request.Template,
+		Params:      request.Params,
+		Tags:        request.Tags,
+		Priority:    request.Priority,
+	}, nil
+
+}
@@ -6,6 +6,7 @@ import (
 	serviceMock "Chronos/internal/service/mocks"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -106,6 +108,44 @@ func TestHandler_GetNotification_Success(t *testing.T) {
 
 }
 
+func TestHandler_GetNotification_Recipients(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?id=00000000-0000-0000-0000-000000000001&recipients=true", nil)
+
+	deliveredAt := time.Now().UTC()
+
+	mockService.EXPECT().GetStatus(gomock.Any(), "00000000-0000-0000-0000-000000000001").Return(models.StatusPartiallySent, nil)
+	mockService.EXPECT().GetRecipients(gomock.Any(), "00000000-0000-0000-0000-000000000001").Return([]models.Recipient{
+		{Address: "neo@matrix.com", Kind: models.RecipientTo, Status: models.StatusSent, Attempts: 1, DeliveredAt: &deliveredAt},
+		{Address: "smith@matrix.com", Kind: models.RecipientTo, Status: models.StatusFailed, Attempts: 2, LastError: "550 no such user"},
+	}, nil)
+
+	handler.GetNotification(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Result     string             `json:"result"`
+		Recipients []models.Recipient `json:"recipients"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.StatusPartiallySent, resp.Result)
+	assert.Len(t, resp.Recipients, 2)
+	assert.Equal(t, "550 no such user", resp.Recipients[1].LastError)
+	assert.NotNil(t, resp.Recipients[0].DeliveredAt)
+
+}
+
 func TestHandler_GetNotification_ErrInvalidID(t *testing.T) {
 
 	controller := gomock.NewController(t)
@@ -273,13 +313,508 @@ func TestHandler_CancelNotification_ErrInvalidID(t *testing.T) {
 
 }
 
-func TestParseTime_MissingSendAt(t *testing.T) {
-	_, err := parseTime("")
+func TestParseSendTime_MissingSendAt(t *testing.T) {
+	_, err := parseSendTime("", "", "")
 	assert.ErrorIs(t, err, errs.ErrMissingSendAt)
 }
 
-func TestMapErrorToStatus_UrgentDeliveryFailed(t *testing.T) {
-	code, msg := mapErrorToStatus(errs.ErrUrgentDeliveryFailed)
+func TestMapErrorToStatus_Internal(t *testing.T) {
+	code, msg := mapErrorToStatus(errors.New("connection refused"))
 	assert.Equal(t, http.StatusInternalServerError, code)
-	assert.Equal(t, errs.ErrUrgentDeliveryFailed.Error(), msg)
+	assert.Equal(t, errs.ErrInternal.Error(), msg)
+}
+
+func TestHandler_CreateTemplate(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	body, _ := json.Marshal(TemplateV1{
+		Name: "welcome",
+		Variants: map[string]TemplateVariantV1{
+			"default": {Message: "Hi {{.name}}"},
+			"email":   {Subject: "Welcome", Message: "Hi {{.name}}", HTMLMessage: "<p>Hi {{.name}}</p>"},
+		},
+	})
+
+	newContext := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return w, c
+	}
+
+	w, c := newContext()
+	mockService.EXPECT().CreateTemplate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, tmpl models.Template) error {
+		assert.Equal(t, "welcome", tmpl.Name)
+		assert.Equal(t, "<p>Hi {{.name}}</p>", tmpl.Variants[models.Email].HTMLMessage)
+		return nil
+	})
+	handler.CreateTemplate(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, c = newContext()
+	mockService.EXPECT().CreateTemplate(gomock.Any(), gomock.Any()).Return(errs.ErrTemplateExists)
+	handler.CreateTemplate(c)
+	assertErrorResponse(t, w, http.StatusConflict, errs.ErrTemplateExists.Error())
+
+}
+
+func TestHandler_GetTemplates(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	mockService.EXPECT().ListTemplates(gomock.Any()).Return([]models.Template{{Name: "a"}, {Name: "b"}}, nil)
+	handler.GetTemplates(c)
+
+	var resp struct {
+		Result []models.Template `json:"result"`
+	}
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Result, 2)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?name=missing", nil)
+
+	mockService.EXPECT().GetTemplate(gomock.Any(), "missing").Return(models.Template{}, errs.ErrTemplateNotFound)
+	handler.GetTemplates(c)
+	assertErrorResponse(t, w, http.StatusNotFound, errs.ErrTemplateNotFound.Error())
+
+}
+
+func TestHandler_DeleteTemplate_InUse(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/?name=welcome", nil)
+
+	mockService.EXPECT().DeleteTemplate(gomock.Any(), "welcome").Return(errs.ErrTemplateInUse)
+	handler.DeleteTemplate(c)
+	assertErrorResponse(t, w, http.StatusConflict, errs.ErrTemplateInUse.Error())
+
+}
+
+func TestHandler_CreateSchedule(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	request := CreateScheduleV1{
+		CreateNotificationV1: CreateNotificationV1{Channel: "telegram", Message: "standup", TimeZone: "Europe/Moscow"},
+		Cron:                 "0 9 * * MON-FRI",
+		EndAt:                "2026-12-31T00:00:00+03:00",
+		MaxOccurrences:       10,
+	}
+
+	newContext := func(request CreateScheduleV1) (*httptest.ResponseRecorder, *gin.Context) {
+		body, _ := json.Marshal(request)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return w, c
+	}
+
+	w, c := newContext(request)
+	mockService.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, s models.Schedule) (string, error) {
+		assert.Equal(t, "0 9 * * MON-FRI", s.Cron)
+		assert.Equal(t, "Europe/Moscow", s.TimeZone)
+		assert.Equal(t, 10, s.MaxOccurrences)
+		assert.True(t, s.StartAt.IsZero())
+		assert.Equal(t, time.Date(2026, 12, 30, 21, 0, 0, 0, time.UTC), *s.EndAt)
+		assert.Equal(t, "standup", s.Notification.Message)
+		return "schedule123", nil
+	})
+	handler.CreateSchedule(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	invalid := request
+	invalid.StartAt = "tomorrow"
+	w, c = newContext(invalid)
+	handler.CreateSchedule(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidScheduleTime.Error())
+
+	w, c = newContext(request)
+	mockService.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return("", errs.ErrInvalidRecurrence)
+	handler.CreateSchedule(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidRecurrence.Error())
+
+}
+
+func TestHandler_CancelSchedule(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/?id=bad", nil)
+	handler.CancelSchedule(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidScheduleID.Error())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/?id=00000000-0000-0000-0000-000000000001", nil)
+	mockService.EXPECT().CancelSchedule(gomock.Any(), "00000000-0000-0000-0000-000000000001").Return(errs.ErrScheduleNotFound)
+	handler.CancelSchedule(c)
+	assertErrorResponse(t, w, http.StatusNotFound, errs.ErrScheduleNotFound.Error())
+
+}
+
+func TestHandler_RescheduleNotification(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	newContext := func(target string, body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPatch, target, bytes.NewReader([]byte(body)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return w, c
+	}
+
+	w, c := newContext("/?id=bad", `{"send_at":"2030-01-01T00:00:00Z"}`)
+	handler.RescheduleNotification(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidNotificationID.Error())
+
+	w, c = newContext("/?id="+id, `{"message":"new"}`)
+	handler.RescheduleNotification(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrMissingSendAt.Error())
+
+	w, c = newContext("/?id="+id, `{"send_at":"2030-01-01T09:00"}`)
+	handler.RescheduleNotification(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidSendAt.Error())
+
+	w, c = newContext("/?id="+id, `{"send_at":"2030-01-01T03:00:00+03:00","message":"new"}`)
+	mockService.EXPECT().RescheduleNotification(gomock.Any(), id, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, _ string, sendAt time.Time, message *string) error {
+			assert.True(t, sendAt.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+			require.NotNil(t, message)
+			assert.Equal(t, "new", *message)
+			return nil
+		})
+	handler.RescheduleNotification(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, c = newContext("/?id="+id, `{"send_at":"2030-01-01T09:00","time_zone":"Europe/Moscow"}`)
+	mockService.EXPECT().RescheduleNotification(gomock.Any(), id, gomock.Any(), nil).
+		DoAndReturn(func(_ any, _ string, sendAt time.Time, _ *string) error {
+			assert.True(t, sendAt.Equal(time.Date(2030, 1, 1, 6, 0, 0, 0, time.UTC)))
+			return nil
+		})
+	handler.RescheduleNotification(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, c = newContext("/?id="+id, `{"send_at":"2030-01-01T09:00","time_zone":"Mars/Olympus"}`)
+	handler.RescheduleNotification(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidTimeZone.Error())
+
+	w, c = newContext("/?id="+id, `{"send_at":"2030-01-01T00:00:00Z"}`)
+	mockService.EXPECT().RescheduleNotification(gomock.Any(), id, gomock.Any(), nil).Return(errs.ErrCannotReschedule)
+	handler.RescheduleNotification(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrCannotReschedule.Error())
+
+}
+
+func TestParseSendTime(t *testing.T) {
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	t.Run("RFC3339 keeps the caller's offset", func(t *testing.T) {
+		got, err := parseSendTime("2030-01-01T09:00:00+05:00", "", "")
+		require.NoError(t, err)
+		assert.True(t, got.Equal(time.Date(2030, 1, 1, 4, 0, 0, 0, time.UTC)))
+		_, offset := got.Zone()
+		assert.Equal(t, 5*60*60, offset)
+	})
+
+	t.Run("local time in time zone", func(t *testing.T) {
+		got, err := parseSendTime("2030-01-01T09:00", "", "Europe/Moscow")
+		require.NoError(t, err)
+		assert.True(t, got.Equal(time.Date(2030, 1, 1, 9, 0, 0, 0, moscow)))
+		assert.Equal(t, moscow, got.Location())
+	})
+
+	t.Run("RFC3339 converted to time zone", func(t *testing.T) {
+		got, err := parseSendTime("2030-01-01T06:00:00Z", "", "Europe/Moscow")
+		require.NoError(t, err)
+		assert.Equal(t, "2030-01-01 09:00", got.Format("2006-01-02 15:04"))
+	})
+
+	t.Run("send_in", func(t *testing.T) {
+		got, err := parseSendTime("", "2h30m", "")
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(150*time.Minute), got, time.Second)
+	})
+
+	errorCases := []struct {
+		name                     string
+		sendAt, sendIn, timeZone string
+		err                      error
+	}{
+		{"both", "2030-01-01T09:00:00Z", "15m", "", errs.ErrSendAtWithSendIn},
+		{"negative send_in", "", "-15m", "", errs.ErrInvalidSendIn},
+		{"invalid send_in", "", "soon", "", errs.ErrInvalidSendIn},
+		{"invalid send_at", "tomorrow", "", "", errs.ErrInvalidSendAt},
+		{"local time without zone", "2030-01-01T09:00", "", "", errs.ErrInvalidSendAt},
+		{"unknown zone", "2030-01-01T09:00", "", "Mars/Olympus", errs.ErrInvalidTimeZone},
+		{"server zone", "2030-01-01T09:00", "", "Local", errs.ErrInvalidTimeZone},
+	}
+
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSendTime(tt.sendAt, tt.sendIn, tt.timeZone)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+}
+
+func TestHandler_CreateNotification_IdempotencyKey(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	newContext := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("Idempotency-Key", "order-42")
+		return w, c
+	}
+
+	var hashes []string
+	mockService.EXPECT().CreateNotificationOnce(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ any, _ models.Notification, key models.IdempotencyKey) (string, error) {
+			assert.Equal(t, "order-42", key.Key)
+			hashes = append(hashes, key.RequestHash)
+			return "notification123", nil
+		})
+
+	for _, body := range []string{
+		`{"channel":"stdout","message":"hi","send_in":"15m"}`,
+		`{ "send_in": "15m", "message": "hi", "channel": "stdout" }`,
+		`{"channel":"stdout","message":"bye","send_in":"15m"}`,
+	} {
+		w, c := newContext(body)
+		handler.CreateNotification(c)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	assert.Equal(t, hashes[0], hashes[1], "formatting must not change the request hash")
+	assert.NotEqual(t, hashes[0], hashes[2])
+
+	w, c := newContext(`{"channel":"stdout","message":"bye","send_in":"15m"}`)
+	mockService.EXPECT().CreateNotificationOnce(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errs.ErrIdempotencyKeyReused)
+	handler.CreateNotification(c)
+	assertErrorResponse(t, w, http.StatusConflict, errs.ErrIdempotencyKeyReused.Error())
+
+}
+
+func TestHandler_CreateBatch(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	newContext := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return w, c
+	}
+
+	w, c := newContext(`{"notifications":[]}`)
+	handler.CreateBatch(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidBatchSize.Error())
+
+	w, c = newContext(`{"notifications":[
+		{"channel":"stdout","message":"one","send_in":"1h"},
+		{"channel":"stdout","message":"two","send_at":"tomorrow"},
+		{"channel":"pigeon","message":"three","send_in":"1h"},
+		{"channel":"stdout","message":"four","send_in":"1h"}]}`)
+
+	mockService.EXPECT().CreateBatch(gomock.Any(), gomock.Len(3)).Return([]models.BatchResult{
+		{ID: "id1"},
+		{Err: errs.ErrUnsupportedChannel},
+		{Err: errors.New("db hiccup")},
+	}, nil)
+
+	handler.CreateBatch(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Result []BatchResultV1 `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []BatchResultV1{
+		{ID: "id1"},
+		{Error: errs.ErrInvalidSendAt.Error()},
+		{Error: errs.ErrUnsupportedChannel.Error()},
+		{Error: errs.ErrInternal.Error()},
+	}, resp.Result)
+
+}
+
+func TestHandler_CancelByTag(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/?tag=order:123", nil)
+	mockService.EXPECT().CancelByTag(gomock.Any(), "order:123").Return(nil, nil)
+	handler.CancelByTag(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"result":[]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/", nil)
+	mockService.EXPECT().CancelByTag(gomock.Any(), "").Return(nil, errs.ErrInvalidTag)
+	handler.CancelByTag(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidTag.Error())
+
+}
+
+func TestParseExpiry(t *testing.T) {
+
+	sendAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	got, err := parseExpiry("", "", sendAt)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = parseExpiry("2030-01-01T09:30:00Z", "", sendAt)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(sendAt.Add(30*time.Minute)))
+
+	got, err = parseExpiry("", "15m", sendAt)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(sendAt.Add(15*time.Minute)))
+
+	for _, tt := range [][2]string{{"2030-01-01T09:30:00Z", "15m"}, {"in an hour", ""}, {"", "-15m"}, {"", "soon"}} {
+		_, err := parseExpiry(tt[0], tt[1], sendAt)
+		assert.ErrorIs(t, err, errs.ErrInvalidExpiry)
+	}
+
+}
+
+func TestHandler_GetDeadLetters(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	mockService.EXPECT().ListDeadLetters(gomock.Any()).Return(nil, nil)
+	handler.GetDeadLetters(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"result":[]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?id=not-a-uuid", nil)
+	handler.GetDeadLetters(c)
+	assertErrorResponse(t, w, http.StatusBadRequest, errs.ErrInvalidDeadLetterID.Error())
+
+	id := "123e4567-e89b-12d3-a456-426614174000"
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?id="+id, nil)
+	mockService.EXPECT().GetDeadLetter(gomock.Any(), id).Return(models.DeadLetter{}, errs.ErrDeadLetterNotFound)
+	handler.GetDeadLetters(c)
+	assertErrorResponse(t, w, http.StatusNotFound, errs.ErrDeadLetterNotFound.Error())
+
+}
+
+func TestHandler_ReplayDeadLetters(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockService := serviceMock.NewMockService(controller)
+	handler := NewHandler(mockService)
+
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"ids":["a","b"]}`)))
+	mockService.EXPECT().ReplayDeadLetters(gomock.Any(), []string{"a", "b"}).Return([]models.BatchResult{
+		{ID: "a"},
+		{ID: "b", Err: errs.ErrCannotReplay},
+	}, nil)
+	handler.ReplayDeadLetters(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"result":[{"id":"a"},{"error":"dead letter cannot be replayed"}]}`, w.Body.String())
+
 }
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/helpers"
+)
+
+// CreateSchedule handles POST /schedules requests.
+// It parses the JSON body, creates a recurring notification via the service,
+// and returns the generated schedule ID.
+func (h *Handler) CreateSchedule(c *ginext.Context) {
+
+	var request CreateScheduleV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	startAt, err := parseOptionalTime(request.StartAt)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	endAt, err := parseOptionalTime(request.EndAt)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if request.ExpiresAt != "" {
+		respondError(c, errs.ErrInvalidExpiry)
+		return
+	}
+
+	schedule := models.Schedule{
+		Cron:           request.Cron,
+		RRule:          request.RRule,
+		TimeZone:       request.TimeZone,
+		EndAt:          endAt,
+		MaxOccurrences: request.MaxOccurrences,
+		MaxDelay:       request.MaxDelay,
+		Notification: models.Notification{
+			Channel:     request.Channel,
+			Subject:     request.Subject,
+			Message:     request.Message,
+			HTMLMessage: request.HTMLMessage,
+			SendTo:      request.SendTo,
+			CC:          request.CC,
+			BCC:         request.BCC,
+			ReplyTo:     request.ReplyTo,
+			Template:    request.Template,
+			Params:      request.Params,
+			Tags:        request.Tags,
+			Priority:    request.Priority,
+		},
+	}
+
+	if startAt != nil {
+		schedule.StartAt = *startAt
+	}
+
+	id, err := h.service.CreateSchedule(c.Request.Context(), schedule)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, id)
+
+}
+
+// GetSchedule handles GET /schedules?id=<id> requests.
+// It returns the schedule, including its status, the number of occurrences produced so far
+// and the ID and send time of the latest occurrence.
+func (h *Handler) GetSchedule(c *ginext.Context) {
+
+	scheduleID := c.Query("id")
+	if err := helpers.ParseUUID(scheduleID); err != nil {
+		respondError(c, errs.ErrInvalidScheduleID)
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(c.Request.Context(), scheduleID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, schedule)
+
+}
+
+// CancelSchedule handles DELETE /schedules?id=<id> requests.
+// It stops the series and cancels its pending occurrence.
+func (h *Handler) CancelSchedule(c *ginext.Context) {
+
+	scheduleID := c.Query("id")
+	if err := helpers.ParseUUID(scheduleID); err != nil {
+		respondError(c, errs.ErrInvalidScheduleID)
+		return
+	}
+
+	if err := h.service.CancelSchedule(c.Request.Context(), scheduleID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, "canceled")
+
+}
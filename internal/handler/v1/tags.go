@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"Chronos/internal/models"
+
+	"github.com/wb-go/wbf/ginext"
+)
+
+// ListByTag handles GET /notify/tag?tag=<tag> requests.
+// It returns every notification carrying the tag, ordered by send time.
+func (h *Handler) ListByTag(c *ginext.Context) {
+
+	notifications, err := h.service.ListByTag(c.Request.Context(), c.Query("tag"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+
+	respondOK(c, notifications)
+
+}
+
+// CancelByTag handles DELETE /notify/tag?tag=<tag> requests.
+// It cancels every pending or running late notification carrying the tag and returns
+// the IDs of the canceled notifications; the list is empty if there was nothing to cancel.
+func (h *Handler) CancelByTag(c *ginext.Context) {
+
+	canceled, err := h.service.CancelByTag(c.Request.Context(), c.Query("tag"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if canceled == nil {
+		canceled = []string{}
+	}
+
+	respondOK(c, canceled)
+
+}
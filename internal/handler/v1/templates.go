@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+
+	"github.com/wb-go/wbf/ginext"
+)
+
+// CreateTemplate handles POST /templates requests.
+// It parses the JSON body and creates a new message template via the service.
+// Returns a conflict error if a template with the same name already exists.
+func (h *Handler) CreateTemplate(c *ginext.Context) {
+
+	var request TemplateV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	if err := h.service.CreateTemplate(c.Request.Context(), toTemplate(request.Name, request)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, request.Name)
+
+}
+
+// GetTemplates handles GET /templates and GET /templates?name=<name> requests.
+// Without a name all templates are returned, otherwise only the named one.
+func (h *Handler) GetTemplates(c *ginext.Context) {
+
+	name := c.Query("name")
+
+	if name == "" {
+		templates, err := h.service.ListTemplates(c.Request.Context())
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		respondOK(c, templates)
+		return
+	}
+
+	tmpl, err := h.service.GetTemplate(c.Request.Context(), name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, tmpl)
+
+}
+
+// UpdateTemplate handles PUT /templates?name=<name> requests.
+// It replaces all variants of the named template with the ones in the JSON body.
+func (h *Handler) UpdateTemplate(c *ginext.Context) {
+
+	var request TemplateV1
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, errs.ErrInvalidJSON)
+		return
+	}
+
+	name := c.Query("name")
+
+	if err := h.service.UpdateTemplate(c.Request.Context(), toTemplate(name, request)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, name)
+
+}
+
+// DeleteTemplate handles DELETE /templates?name=<name> requests.
+// Templates used by pending or late notifications cannot be deleted.
+func (h *Handler) DeleteTemplate(c *ginext.Context) {
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), c.Query("name")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondOK(c, "deleted")
+
+}
+
+// toTemplate converts a TemplateV1 payload into a models.Template with the given name.
+func toTemplate(name string, request TemplateV1) models.Template {
+
+	variants := make(map[string]models.TemplateVariant, len(request.Variants))
+	for channel, variant := range request.Variants {
+		variants[channel] = models.TemplateVariant{
+			Subject:     variant.Subject,
+			Message:     variant.Message,
+			HTMLMessage: variant.HTMLMessage,
+		}
+	}
+
+	return models.Template{Name: name, Variants: variants}
+
+}
@@ -2,6 +2,9 @@ package v1
 
 import (
 	"Chronos/internal/errs"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -9,20 +12,118 @@ import (
 	"github.com/wb-go/wbf/ginext"
 )
 
-// parseTime parses a string in RFC3339 format into a UTC time.Time value.
-// Returns ErrMissingSendAt if the string is empty, or ErrInvalidSendAt if parsing fails.
-func parseTime(timeStr string) (time.Time, error) {
+// idempotencyKeyHeader is the request header carrying the client's idempotency key.
+const idempotencyKeyHeader = "Idempotency-Key"
 
-	if timeStr == "" {
+// requestHash returns the hex-encoded SHA-256 of the request re-encoded as JSON, so requests
+// that differ only in formatting or key order are considered the same.
+func requestHash(request any) string {
+	body, _ := json.Marshal(request) // DTOs always marshal
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// localLayouts are the accepted formats of a send_at without a UTC offset,
+// which is interpreted in the time zone given alongside it.
+var localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// parseSendTime resolves the send time of a notification from either send_at or send_in.
+// send_at is parsed as RFC3339 or, without an offset, as a local time in timeZone. A local time
+// is rejected when no timeZone is given, since the zone it was meant in is unknown.
+// send_in is a positive duration such as "15m" or "2h30m" counted from now.
+// The result is expressed in the caller's zone: timeZone if set, otherwise the offset of send_at,
+// so the local send time can be rendered in it.
+// Returns ErrMissingSendAt if neither is set, ErrSendAtWithSendIn if both are, ErrInvalidTimeZone,
+// ErrInvalidSendAt or ErrInvalidSendIn if parsing fails.
+func parseSendTime(sendAt string, sendIn string, timeZone string) (time.Time, error) {
+
+	loc := time.UTC
+	if timeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timeZone); err != nil || timeZone == "Local" {
+			return time.Time{}, errs.ErrInvalidTimeZone
+		}
+	}
+
+	switch {
+
+	case sendAt != "" && sendIn != "":
+		return time.Time{}, errs.ErrSendAtWithSendIn
+
+	case sendIn != "":
+		delay, err := time.ParseDuration(sendIn)
+		if err != nil || delay <= 0 {
+			return time.Time{}, errs.ErrInvalidSendIn
+		}
+		return time.Now().Add(delay).In(loc), nil
+
+	case sendAt != "":
+		if validTime, err := time.Parse(time.RFC3339, sendAt); err == nil {
+			if timeZone != "" {
+				validTime = validTime.In(loc)
+			}
+			return validTime, nil
+		}
+		if timeZone == "" {
+			return time.Time{}, errs.ErrInvalidSendAt
+		}
+		for _, layout := range localLayouts {
+			if validTime, err := time.ParseInLocation(layout, sendAt, loc); err == nil {
+				return validTime, nil
+			}
+		}
+		return time.Time{}, errs.ErrInvalidSendAt
+
+	default:
 		return time.Time{}, errs.ErrMissingSendAt
+
+	}
+
+}
+
+// parseOptionalTime parses an optional string in RFC3339 format into a UTC time.Time value.
+// Returns nil if the string is empty, or ErrInvalidScheduleTime if parsing fails.
+func parseOptionalTime(timeStr string) (*time.Time, error) {
+
+	if timeStr == "" {
+		return nil, nil
 	}
 
 	validTime, err := time.Parse(time.RFC3339, timeStr)
 	if err != nil {
-		return time.Time{}, errs.ErrInvalidSendAt
+		return nil, errs.ErrInvalidScheduleTime
 	}
 
-	return validTime.UTC(), nil
+	validTime = validTime.UTC()
+
+	return &validTime, nil
+
+}
+
+// parseExpiry converts the expires_at or max_delay field of a request into a delivery deadline.
+// The max delay is counted from sendAt. Both fields are optional but mutually exclusive;
+// whether the deadline is after the send time is checked by the service.
+func parseExpiry(expiresAt, maxDelay string, sendAt time.Time) (*time.Time, error) {
+
+	switch {
+	case expiresAt != "" && maxDelay != "":
+		return nil, errs.ErrInvalidExpiry
+	case expiresAt != "":
+		deadline, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return nil, errs.ErrInvalidExpiry
+		}
+		return &deadline, nil
+	case maxDelay != "":
+		d, err := time.ParseDuration(maxDelay)
+		if err != nil || d <= 0 {
+			return nil, errs.ErrInvalidExpiry
+		}
+		deadline := sendAt.Add(d)
+		return &deadline, nil
+	}
+
+	return nil, nil
 
 }
 
@@ -41,7 +142,7 @@ func respondError(c *ginext.Context, err error) {
 }
 
 // mapErrorToStatus converts a known error to an appropriate HTTP status code and message.
-// Returns 400 for validation errors, 404 for not found, and 500 for internal errors.
+// Returns 400 for validation errors, 404 for not found, 409 for conflicts, and 500 for internal errors.
 func mapErrorToStatus(err error) (int, string) {
 
 	switch {
@@ -53,6 +154,15 @@ func mapErrorToStatus(err error) (int, string) {
 		errors.Is(err, errs.ErrMessageTooLong),
 		errors.Is(err, errs.ErrMissingSendAt),
 		errors.Is(err, errs.ErrInvalidSendAt),
+		errors.Is(err, errs.ErrInvalidSendIn),
+		errors.Is(err, errs.ErrSendAtWithSendIn),
+		errors.Is(err, errs.ErrInvalidIdempotencyKey),
+		errors.Is(err, errs.ErrInvalidBatchSize),
+		errors.Is(err, errs.ErrInvalidTag),
+		errors.Is(err, errs.ErrInvalidExpiry),
+		errors.Is(err, errs.ErrInvalidDeadLetterID),
+		errors.Is(err, errs.ErrCannotReplay),
+		errors.Is(err, errs.ErrInvalidPriority),
 		errors.Is(err, errs.ErrSendAtInPast),
 		errors.Is(err, errs.ErrSendAtTooFar),
 		errors.Is(err, errs.ErrMissingSendTo),
@@ -61,16 +171,37 @@ func mapErrorToStatus(err error) (int, string) {
 		errors.Is(err, errs.ErrInvalidEmailFormat),
 		errors.Is(err, errs.ErrCannotCancel),
 		errors.Is(err, errs.ErrAlreadyCanceled),
-		errors.Is(err, errs.ErrRecipientTooLong):
+		errors.Is(err, errs.ErrCannotReschedule),
+		errors.Is(err, errs.ErrRecipientTooLong),
+		errors.Is(err, errs.ErrInvalidTelegramChat),
+		errors.Is(err, errs.ErrInvalidWebhookURL),
+		errors.Is(err, errs.ErrTooManyRecipients),
+		errors.Is(err, errs.ErrInvalidTemplateName),
+		errors.Is(err, errs.ErrInvalidTemplate),
+		errors.Is(err, errs.ErrUnknownTemplate),
+		errors.Is(err, errs.ErrTemplateWithMessage),
+		errors.Is(err, errs.ErrTemplateRender),
+		errors.Is(err, errs.ErrInvalidRecurrence),
+		errors.Is(err, errs.ErrInvalidTimeZone),
+		errors.Is(err, errs.ErrInvalidScheduleID),
+		errors.Is(err, errs.ErrInvalidScheduleTime),
+		errors.Is(err, errs.ErrInvalidScheduleBounds),
+		errors.Is(err, errs.ErrNoOccurrences),
+		errors.Is(err, errs.ErrScheduleNotActive):
 		return http.StatusBadRequest, err.Error()
 
-	case errors.Is(err, errs.ErrNotificationNotFound):
+	case errors.Is(err, errs.ErrNotificationNotFound),
+		errors.Is(err, errs.ErrTemplateNotFound),
+		errors.Is(err, errs.ErrScheduleNotFound),
+		errors.Is(err, errs.ErrDeadLetterNotFound):
 		return http.StatusNotFound, err.Error()
 
+	case errors.Is(err, errs.ErrTemplateExists),
+		errors.Is(err, errs.ErrTemplateInUse),
+		errors.Is(err, errs.ErrIdempotencyKeyReused):
+		return http.StatusConflict, err.Error()
+
 	default:
-		if errors.Is(err, errs.ErrUrgentDeliveryFailed) {
-			return http.StatusInternalServerError, err.Error()
-		}
 		return http.StatusInternalServerError, errs.ErrInternal.Error()
 	}
 
@@ -8,34 +8,185 @@ import "time"
 // It contains information about the delivery channel, message content,
 // recipients, status, and timestamps.
 type Notification struct {
-	ID          string    `json:"id"`            // Unique identifier for the notification
-	Channel     string    `json:"channel"`       // Delivery channel
-	Subject     string    `json:"subject"`       // Subject or title of the notification
-	Message     string    `json:"message"`       // Main content of the notification
-	Status      string    `json:"status"`        // Current status of the notification
-	SendAt      time.Time `json:"send_at"`       // Scheduled UTC time for sending
-	SendAtLocal string    `json:"send_at_local"` // Scheduled time in local timezone
-	SendTo      []string  `json:"send_to"`       // List of recipients
-	UpdatedAt   time.Time `json:"updated_at"`    // Last update timestamp
+	ID          string         `json:"id"`                    // Unique identifier for the notification
+	Channel     string         `json:"channel"`               // Delivery channel
+	Subject     string         `json:"subject"`               // Subject or title of the notification
+	Message     string         `json:"message"`               // Main content of the notification
+	Status      string         `json:"status"`                // Current status of the notification
+	SendAt      time.Time      `json:"send_at"`               // Scheduled UTC time for sending
+	SendAtLocal string         `json:"send_at_local"`         // Scheduled time in the caller's time zone
+	TimeZone    string         `json:"time_zone,omitempty"`   // Caller's IANA time zone, empty if only a UTC offset was given
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`  // Delivery deadline; the notification expires instead of being sent after it
+	SendTo      []string       `json:"send_to"`               // List of recipients
+	CC          []string       `json:"cc"`                    // List of carbon copy recipients (email only)
+	BCC         []string       `json:"bcc"`                   // List of blind carbon copy recipients (email only)
+	ReplyTo     string         `json:"reply_to"`              // Reply-To address (email only)
+	HTMLMessage string         `json:"html_message"`          // HTML alternative of the message (email only)
+	MessageID   string         `json:"message_id"`            // Generated Message-ID header (email only)
+	Template    string         `json:"template,omitempty"`    // Name of the template the message is rendered from
+	Params      map[string]any `json:"params,omitempty"`      // Template parameters
+	ScheduleID  string         `json:"schedule_id,omitempty"` // ID of the recurring schedule the notification is an occurrence of
+	Revision    int            `json:"revision,omitempty"`    // Number of times the notification has been rescheduled
+	Attempts    int            `json:"attempts,omitempty"`    // Number of delivery attempts made so far
+	Tags        []string       `json:"tags,omitempty"`        // Tags used to list and cancel related notifications, such as "order:123"
+	Priority    string         `json:"priority"`              // Delivery priority selecting the queue the notification is delivered from
+	Recipients  []Recipient    `json:"recipients,omitempty"`  // Per-recipient delivery state
+	UpdatedAt   time.Time      `json:"updated_at"`            // Last update timestamp
+}
+
+// Recipient is the delivery state of a single recipient of a notification.
+type Recipient struct {
+	Address     string     `json:"address"`                // Recipient as listed in send_to, cc or bcc
+	Kind        string     `json:"kind"`                   // Recipient kind: to, cc or bcc
+	Status      string     `json:"status"`                 // Delivery status: pending, sent or failed to send
+	Attempts    int        `json:"attempts"`               // Number of delivery attempts
+	LastError   string     `json:"last_error,omitempty"`   // Error of the last failed attempt
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"` // Time of successful delivery
+}
+
+// Template is a named message template. Variants are keyed by channel name;
+// the DefaultVariant is used for channels without their own variant.
+// Subjects and messages use text/template syntax, HTML messages use html/template syntax.
+type Template struct {
+	Name      string                     `json:"name"`       // Unique template name
+	Variants  map[string]TemplateVariant `json:"variants"`   // Variants keyed by channel name or DefaultVariant
+	CreatedAt time.Time                  `json:"created_at"` // Creation timestamp
+	UpdatedAt time.Time                  `json:"updated_at"` // Last update timestamp
+}
+
+// TemplateVariant is the wording of a template for a single channel.
+type TemplateVariant struct {
+	Subject     string `json:"subject,omitempty"`      // Subject template
+	Message     string `json:"message"`                // Plain-text message template
+	HTMLMessage string `json:"html_message,omitempty"` // HTML message template (email only)
+}
+
+// Variant returns the variant of the template for the channel, falling back to the DefaultVariant.
+func (t Template) Variant(channel string) (TemplateVariant, bool) {
+	if variant, ok := t.Variants[channel]; ok {
+		return variant, true
+	}
+	variant, ok := t.Variants[DefaultVariant]
+	return variant, ok
+}
+
+// Schedule is a recurring notification. Its occurrences are produced one at a time:
+// the next occurrence is created when the current one has been processed.
+// Exactly one of Cron and RRule is set; both are evaluated in TimeZone.
+type Schedule struct {
+	ID             string       `json:"id"`                        // Unique identifier for the schedule
+	Cron           string       `json:"cron,omitempty"`            // Five-field cron expression
+	RRule          string       `json:"rrule,omitempty"`           // RFC 5545 recurrence rule
+	TimeZone       string       `json:"time_zone"`                 // IANA time zone the rule is evaluated in
+	StartAt        time.Time    `json:"start_at"`                  // No occurrences before this time; DTSTART of an RRULE
+	EndAt          *time.Time   `json:"end_at,omitempty"`          // No occurrences after this time
+	MaxOccurrences int          `json:"max_occurrences,omitempty"` // Maximum number of occurrences, 0 for unlimited
+	MaxDelay       string       `json:"max_delay,omitempty"`       // Delivery deadline of every occurrence relative to its send time, such as "15m"
+	Occurrences    int          `json:"occurrences"`               // Number of occurrences produced so far
+	Status         string       `json:"status"`                    // Current status of the schedule
+	CurrentID      string       `json:"current_id"`                // ID of the latest occurrence
+	NextAt         time.Time    `json:"next_at"`                   // Send time of the latest occurrence
+	Notification   Notification `json:"notification"`              // Notification every occurrence is created from
+	CreatedAt      time.Time    `json:"created_at"`                // Creation timestamp
+	UpdatedAt      time.Time    `json:"updated_at"`                // Last update timestamp
+}
+
+// Delivered reports whether the notification has already been delivered to the recipient.
+// Channels use it to skip recipients that were served by a previous attempt.
+func (n Notification) Delivered(recipient string) bool {
+	for _, r := range n.Recipients {
+		if r.Address == recipient && r.Status == StatusSent {
+			return true
+		}
+	}
+	return false
+}
+
+// IdempotencyKey maps a client-supplied Idempotency-Key to the notification created by the
+// first request that used it, so that retries of that request return the same notification.
+type IdempotencyKey struct {
+	Key            string // Client-supplied key
+	RequestHash    string // SHA-256 of the request body the key was first used with
+	NotificationID string // ID of the notification created by the first request
+}
+
+// BatchResult is the outcome of a single notification of a batch: the ID of the created
+// notification, or the error that prevented its creation.
+type BatchResult struct {
+	ID  string // ID of the created notification
+	Err error  // Validation or delivery error, nil on success
+}
+
+// DeadLetter is a message that could not be delivered: a notification that used up its delivery
+// attempts, or a message that could not be processed at all. Dead letters are kept for inspection
+// and the notifications they carry can be replayed.
+type DeadLetter struct {
+	ID             string     `json:"id"`                    // Unique identifier for the dead letter
+	NotificationID string     `json:"notification_id"`       // ID of the notification, empty if the message could not be decoded
+	Reason         string     `json:"reason"`                // Error that made the message a dead letter
+	Body           string     `json:"body,omitempty"`        // Raw message body
+	Attempts       int        `json:"attempts"`              // Number of delivery attempts made before giving up
+	CreatedAt      time.Time  `json:"created_at"`            // Time the message was dead-lettered
+	ReplayedAt     *time.Time `json:"replayed_at,omitempty"` // Time the notification was replayed, nil if it has not been
+}
+
+// OutboxEntry is an unpublished outbox entry together with the notification it enqueues.
+// A notification gets a new entry every time it is created, rescheduled or replayed,
+// so entries are published and marked by their own ID rather than by the notification's.
+type OutboxEntry struct {
+	ID           int64        // Outbox entry ID
+	Notification Notification // Notification to publish
 }
 
 const (
 	StatusPending            = "pending"                // Notification is created but not yet sent
 	StatusCanceled           = "canceled"               // Notification has been canceled
-	StatusFailedToSendInTime = "failed to send in time" // Notification failed to send before scheduled time
+	StatusFailedToSendInTime = "failed to send in time" // Notification was sent late by an earlier version; late notifications are now sent or expired
 	StatusFailed             = "failed to send"         // Notification failed to send due to error
 	StatusLate               = "running late"           // Notification delayed past its scheduled send time
 	StatusSent               = "sent"                   // Notification was successfully sent
+	StatusPartiallySent      = "partially sent"         // Notification was delivered to some recipients only
+	StatusExpired            = "expired"                // Notification was not sent because its delivery deadline had passed
+	StatusSending            = "sending"                // Notification has been claimed by a consumer and is being sent
+)
+
+const (
+	PriorityHigh   = "high"   // Time-critical notifications, such as security codes
+	PriorityNormal = "normal" // Default priority
+	PriorityBulk   = "bulk"   // Notifications that may wait behind everything else, such as marketing campaigns
+)
+
+const (
+	ScheduleActive   = "active"   // Schedule keeps producing occurrences
+	ScheduleFinished = "finished" // Schedule has produced its last occurrence
+)
+
+const DefaultVariant = "default" // Template variant used for channels without their own variant
+
+const (
+	RecipientTo  = "to"  // Primary recipient
+	RecipientCC  = "cc"  // Carbon copy recipient
+	RecipientBCC = "bcc" // Blind carbon copy recipient
 )
 
 const (
 	Email    = "email"    // Email channel
 	Stdout   = "stdout"   // Standard output
 	Telegram = "telegram" // Telegram bot channel
+	Webhook  = "webhook"  // Outbound HTTP webhook channel
 )
 
 const (
-	MaxEmailLength   = 254 // Maximum length for email addresses
-	MaxSubjectLength = 254 // Maximum length for email subject
-	MaxMessageLength = 254 // Maximum length for message content
+	MaxEmailLength    = 254 // Maximum length for email addresses
+	MaxSubjectLength  = 254 // Maximum length for email subject
+	MaxMessageLength  = 254 // Maximum length for message content
+	MaxURLLength      = 254 // Maximum length for webhook URLs
+	MaxTemplateName   = 64  // Maximum length for template names
+	MaxIdempotencyKey = 255 // Maximum length for idempotency keys
+
+	MaxHTMLMessageLength = 1 << 16 // Maximum length for HTML message content
+	MaxBatchSize         = 1000    // Maximum number of notifications in a batch
+	MaxTags              = 16      // Maximum number of tags per notification
+	MaxTagLength         = 64      // Maximum length for tags
+	MaxDeadLetters       = 1000    // Maximum number of dead letters listed at once
 )
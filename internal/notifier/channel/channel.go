@@ -0,0 +1,30 @@
+// Package channel contains the built-in delivery channels: Email, Telegram, Webhook, and Stdout.
+// Each channel implements notifier.Channel and is registered in the notifier registry at boot.
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"fmt"
+	"strings"
+)
+
+// New creates the built-in channel with the given name using the notifier configuration.
+// Returns an error if there is no built-in channel with that name or its settings are invalid.
+func New(name string, config config.Notifier) (notifier.Channel, error) {
+
+	switch strings.ToLower(name) {
+	case models.Email:
+		return NewEmail(config)
+	case models.Telegram:
+		return NewTelegram(config), nil
+	case models.Webhook:
+		return NewWebhook(config)
+	case models.Stdout:
+		return NewStdout(), nil
+	default:
+		return nil, fmt.Errorf("unknown built-in channel: %s", name)
+	}
+
+}
@@ -0,0 +1,170 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Email sends notifications to email recipients using SMTP.
+// Messages are composed as RFC 5322/MIME with HTML and plain-text alternatives
+// and delivered over pooled SMTP connections shared by all consumer workers.
+type Email struct {
+	sender    string         // Email address to send from
+	smtpAddr  string         // SMTP server address (host:port)
+	transport *smtpTransport // pooled SMTP transport
+}
+
+// NewEmail creates a new Email channel based on the configuration.
+// Returns an error if the SMTP transport settings are invalid.
+func NewEmail(config config.Notifier) (*Email, error) {
+
+	transport, err := newSMTPTransport(config.EmailSMTPAddr, config.EmailSMTP,
+		config.EmailSender, config.EmailPassword, config.SMTP)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Email{
+		sender:    config.EmailSender,
+		smtpAddr:  config.EmailSMTPAddr,
+		transport: transport,
+	}, nil
+
+}
+
+// Name returns the channel name.
+func (e *Email) Name() string {
+	return models.Email
+}
+
+// Capabilities reports that email requires recipients and uses the subject.
+func (e *Email) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{
+		Subject:            true,
+		RequiresRecipients: true,
+		MaxMessageLength:   models.MaxMessageLength,
+	}
+}
+
+// Prepare generates the Message-ID of a new email notification.
+func (e *Email) Prepare(notification *models.Notification) {
+	notification.MessageID = newMessageID(notification.ID, e.sender)
+}
+
+// Validate checks that the subject is set, the HTML message fits its limit,
+// and every recipient, CC, BCC and Reply-To entry is a valid email address.
+func (e *Email) Validate(notification models.Notification) error {
+
+	if notification.Subject == "" {
+		return errs.ErrMissingEmailSubject
+	}
+
+	if utf8.RuneCountInString(notification.Subject) > models.MaxSubjectLength {
+		return errs.ErrEmailSubjectTooLong
+	}
+
+	if utf8.RuneCountInString(notification.HTMLMessage) > models.MaxHTMLMessageLength {
+		return errs.ErrMessageTooLong
+	}
+
+	for _, list := range [][]string{notification.SendTo, notification.CC, notification.BCC} {
+		for _, recipient := range list {
+			if err := validateEmail(recipient); err != nil {
+				return err
+			}
+		}
+	}
+
+	if notification.ReplyTo != "" {
+		if err := validateEmail(notification.ReplyTo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// validateEmail checks that a single recipient is a valid email address.
+func validateEmail(recipient string) error {
+
+	if recipient == "" {
+		return errs.ErrInvalidEmailFormat
+	}
+
+	if len(recipient) > models.MaxEmailLength {
+		return errs.ErrRecipientTooLong
+	}
+
+	addr, err := mail.ParseAddress(recipient)
+	if err != nil {
+		return errs.ErrInvalidEmailFormat
+	}
+
+	parts := strings.Split(addr.Address, "@")
+	if len(parts) != 2 || !strings.Contains(parts[1], ".") {
+		return errs.ErrInvalidEmailFormat
+	}
+
+	return nil
+
+}
+
+// Send composes a MIME message and sends it to all To, CC and BCC recipients using SMTP.
+// Recipients that already received the notification are skipped. Recipients refused
+// by the SMTP server are reported as notifier.RecipientErrors.
+func (e *Email) Send(ctx context.Context, notification models.Notification) error {
+
+	message, err := composeEmail(e.sender, notification, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to compose email: %w", err)
+	}
+
+	var pending []string
+	var envelope []string
+	for _, list := range [][]string{notification.SendTo, notification.CC, notification.BCC} {
+		for _, recipient := range list {
+			if notification.Delivered(recipient) {
+				continue
+			}
+			pending = append(pending, recipient)
+			envelope = append(envelope, envelopeAddress(recipient))
+		}
+	}
+
+	if len(envelope) == 0 {
+		return nil
+	}
+
+	rejected, err := e.transport.send(ctx, envelopeAddress(e.sender), envelope, message)
+	if err != nil && len(rejected) == 0 {
+		return fmt.Errorf("failed to send email to %v via SMTP server %s: %w", envelope, e.smtpAddr, err)
+	}
+
+	failed := make(notifier.RecipientErrors)
+	for _, recipient := range pending {
+		if reason, ok := rejected[envelopeAddress(recipient)]; ok {
+			failed[recipient] = reason
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+
+	return nil
+
+}
+
+// Close closes the idle SMTP connections.
+func (e *Email) Close() {
+	e.transport.close()
+}
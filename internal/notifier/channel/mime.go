@@ -0,0 +1,139 @@
+package channel
+
+import (
+	"Chronos/internal/models"
+	"bytes"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	crlf             = "\r\n"
+	defaultMsgIDHost = "chronos.local"
+)
+
+// composeEmail builds an RFC 5322 message with a multipart/alternative body
+// containing the plain-text message and its HTML alternative.
+// If the notification has no HTML message, one is derived from the plain text.
+// BCC recipients are never written to the headers.
+func composeEmail(from string, notification models.Notification, date time.Time) ([]byte, error) {
+
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	headers := []struct{ key, value string }{
+		{"From", formatAddress(from)},
+		{"To", formatAddressList(notification.SendTo)},
+		{"Cc", formatAddressList(notification.CC)},
+		{"Reply-To", formatAddress(notification.ReplyTo)},
+		{"Subject", mime.QEncoding.Encode("utf-8", notification.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", notification.MessageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + body.Boundary()},
+	}
+
+	var message bytes.Buffer
+	for _, header := range headers {
+		if header.value != "" {
+			message.WriteString(header.key + ": " + header.value + crlf)
+		}
+	}
+	message.WriteString(crlf)
+
+	htmlMessage := notification.HTMLMessage
+	if htmlMessage == "" {
+		htmlMessage = textToHTML(notification.Message)
+	}
+
+	if err := writePart(body, "text/plain; charset=UTF-8", notification.Message); err != nil {
+		return nil, fmt.Errorf("failed to write text part: %w", err)
+	}
+
+	if err := writePart(body, "text/html; charset=UTF-8", htmlMessage); err != nil {
+		return nil, fmt.Errorf("failed to write html part: %w", err)
+	}
+
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	message.Write(buf.Bytes())
+
+	return message.Bytes(), nil
+
+}
+
+// writePart writes a single quoted-printable encoded part of a multipart body.
+func writePart(body *multipart.Writer, contentType string, content string) error {
+
+	part, err := body.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+
+}
+
+// textToHTML escapes a plain-text message and preserves its line breaks.
+func textToHTML(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = strings.ReplaceAll(escaped, "\r\n", "\n")
+	return "<html><body><p>" + strings.ReplaceAll(escaped, "\n", "<br>") + "</p></body></html>"
+}
+
+// formatAddress formats an address for a header, encoding a display name if present.
+// Returns the input unchanged if it cannot be parsed.
+func formatAddress(address string) string {
+	if address == "" {
+		return ""
+	}
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.String()
+}
+
+// formatAddressList formats a list of addresses as a comma-separated header value.
+func formatAddressList(addresses []string) string {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		formatted = append(formatted, formatAddress(address))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// envelopeAddress returns the bare address used in the SMTP envelope.
+func envelopeAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.Address
+}
+
+// newMessageID generates a Message-ID for the notification using the sender's domain.
+func newMessageID(notificationID string, sender string) string {
+	host := defaultMsgIDHost
+	if _, domain, ok := strings.Cut(envelopeAddress(sender), "@"); ok && domain != "" {
+		host = domain
+	}
+	return "<" + notificationID + "@" + host + ">"
+}
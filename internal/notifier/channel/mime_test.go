@@ -0,0 +1,102 @@
+package channel
+
+import (
+	"Chronos/internal/models"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeEmail(t *testing.T) {
+
+	notification := models.Notification{
+		ID:        "00000000-0000-0000-0000-000000000001",
+		Subject:   "Напоминание о встрече",
+		Message:   "Встреча в 10:00\nне опаздывайте",
+		SendTo:    []string{"Neo <neo@matrix.com>", "trinity@matrix.com"},
+		CC:        []string{"morpheus@matrix.com"},
+		BCC:       []string{"smith@matrix.com"},
+		ReplyTo:   "oracle@matrix.com",
+		MessageID: "<00000000-0000-0000-0000-000000000001@matrix.com>",
+	}
+
+	date := time.Date(2026, 1, 9, 5, 40, 0, 0, time.UTC)
+
+	raw, err := composeEmail("chronos@matrix.com", notification, date)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, notification.Subject, subject)
+
+	assert.Equal(t, "<chronos@matrix.com>", msg.Header.Get("From"))
+	assert.Equal(t, `"Neo" <neo@matrix.com>, <trinity@matrix.com>`, msg.Header.Get("To"))
+	assert.Equal(t, "<morpheus@matrix.com>", msg.Header.Get("Cc"))
+	assert.Equal(t, "<oracle@matrix.com>", msg.Header.Get("Reply-To"))
+	assert.Equal(t, notification.MessageID, msg.Header.Get("Message-ID"))
+	assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+	assert.Empty(t, msg.Header.Get("Bcc"))
+	assert.NotContains(t, string(raw), "smith@matrix.com")
+
+	parsedDate, err := msg.Header.Date()
+	require.NoError(t, err)
+	assert.True(t, date.Equal(parsedDate))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	var bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, parts)
+	assert.Equal(t, strings.ReplaceAll(notification.Message, "\n", "\r\n"), bodies[0])
+	assert.True(t, strings.Contains(bodies[1], "Встреча в 10:00<br>не опаздывайте"))
+
+}
+
+func TestComposeEmail_HTMLMessage(t *testing.T) {
+
+	notification := models.Notification{
+		Subject:     "plain ascii",
+		Message:     "text version",
+		HTMLMessage: "<b>html version</b>",
+		SendTo:      []string{"neo@matrix.com"},
+	}
+
+	raw, err := composeEmail("chronos@matrix.com", notification, time.Now())
+	require.NoError(t, err)
+
+	assert.Contains(t, string(raw), "Subject: plain ascii\r\n")
+	assert.Contains(t, string(raw), "<b>html version</b>")
+	assert.NotContains(t, string(raw), "Reply-To")
+
+}
+
+func TestNewMessageID(t *testing.T) {
+	assert.Equal(t, "<id@matrix.com>", newMessageID("id", "Chronos <chronos@matrix.com>"))
+	assert.Equal(t, "<id@chronos.local>", newMessageID("id", ""))
+}
@@ -0,0 +1,430 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	smtpTLSImplicit      = "implicit"
+	smtpTLSStartTLS      = "starttls"
+	smtpTLSOpportunistic = "opportunistic"
+	smtpTLSNone          = "none"
+
+	smtpAuthPlain   = "plain"
+	smtpAuthLogin   = "login"
+	smtpAuthCRAMMD5 = "cram-md5"
+	smtpAuthNone    = "none"
+
+	defaultSMTPPoolSize    = 4
+	defaultSMTPIdleTimeout = 30 * time.Second
+	defaultSMTPDialTimeout = 10 * time.Second
+)
+
+// smtpTransport delivers composed messages to an SMTP server.
+// Connections are established according to the configured TLS policy, authenticated once,
+// and kept in a pool so that consumer workers reuse them instead of handshaking per message.
+type smtpTransport struct {
+	addr        string        // SMTP server address (host:port)
+	host        string        // SMTP server host used for TLS verification and authentication
+	tlsMode     string        // implicit, starttls, opportunistic or none
+	tlsConfig   *tls.Config   // TLS settings for implicit TLS and STARTTLS
+	auth        smtp.Auth     // authentication mechanism, nil if authentication is disabled
+	poolSize    int           // maximum number of idle connections kept for reuse
+	idleTimeout time.Duration // idle connections older than this are closed instead of reused
+	dialTimeout time.Duration // timeout for establishing a new connection
+
+	mu     sync.Mutex  // guards idle and closed
+	idle   []*smtpConn // idle authenticated connections ready for the next message
+	closed bool        // set by close; connections returned afterwards are closed
+}
+
+// smtpConn is a pooled SMTP client connection.
+type smtpConn struct {
+	client   *smtp.Client // authenticated SMTP client
+	conn     net.Conn     // underlying network connection, used to enforce send deadlines
+	lastUsed time.Time    // time the connection was last returned to the pool
+}
+
+// newSMTPTransport creates an SMTP transport based on the configuration.
+// Returns an error if the TLS policy or authentication mechanism is unknown, or the CA file cannot be loaded.
+func newSMTPTransport(addr string, host string, username string, password string, config config.SMTP) (*smtpTransport, error) {
+
+	if host == "" {
+		if h, _, err := net.SplitHostPort(addr); err == nil {
+			host = h
+		}
+	}
+
+	tlsMode := strings.ToLower(config.TLS)
+	switch tlsMode {
+	case "":
+		tlsMode = smtpTLSOpportunistic
+	case smtpTLSImplicit, smtpTLSStartTLS, smtpTLSOpportunistic, smtpTLSNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode: %s", config.TLS)
+	}
+
+	var auth smtp.Auth
+	switch strings.ToLower(config.Auth) {
+	case "", smtpAuthPlain:
+		auth = smtp.PlainAuth("", username, password, host)
+	case smtpAuthLogin:
+		auth = &loginAuth{username: username, password: password, host: host}
+	case smtpAuthCRAMMD5:
+		auth = smtp.CRAMMD5Auth(username, password)
+	case smtpAuthNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP auth mechanism: %s", config.Auth)
+	}
+
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	if config.CAFile != "" {
+		rootCAs, err := loadCertPool(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport := &smtpTransport{
+		addr:        addr,
+		host:        host,
+		tlsMode:     tlsMode,
+		tlsConfig:   tlsConfig,
+		auth:        auth,
+		poolSize:    config.PoolSize,
+		idleTimeout: config.IdleTimeout,
+		dialTimeout: config.DialTimeout,
+	}
+
+	if transport.poolSize <= 0 {
+		transport.poolSize = defaultSMTPPoolSize
+	}
+	if transport.idleTimeout <= 0 {
+		transport.idleTimeout = defaultSMTPIdleTimeout
+	}
+	if transport.dialTimeout <= 0 {
+		transport.dialTimeout = defaultSMTPDialTimeout
+	}
+
+	return transport, nil
+
+}
+
+// loadCertPool returns the system certificate pool extended with the certificates from a PEM file.
+func loadCertPool(path string) (*x509.CertPool, error) {
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SMTP CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in SMTP CA file %s", path)
+	}
+
+	return pool, nil
+
+}
+
+// send delivers a message over a pooled connection, dialing a new one if none is available.
+// The connection is returned to the pool if it is still usable afterwards.
+// If ctx is done mid-transaction, the connection is aborted and discarded.
+// Recipients refused by the server are returned with their errors; the message is
+// still delivered to the others. If every recipient is refused, an error is returned as well.
+func (t *smtpTransport) send(ctx context.Context, from string, to []string, message []byte) (map[string]error, error) {
+
+	conn, err := t.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	release := guard(ctx, conn.conn)
+
+	rejected, err := deliver(conn.client, from, to, message)
+	if err != nil && ctx.Err() == nil {
+		if resetErr := conn.client.Reset(); resetErr != nil {
+			_ = conn.client.Close()
+			release()
+			return rejected, err
+		}
+	}
+
+	if !release() {
+		_ = conn.client.Close()
+		return rejected, errors.Join(err, ctx.Err())
+	}
+
+	t.put(conn)
+
+	return rejected, err
+
+}
+
+// guard bounds I/O on conn by the deadline of ctx and aborts it as soon as ctx is done.
+// The returned release function clears the deadline and reports whether the
+// connection is still usable, that is, ctx was not done while it was guarded.
+func guard(ctx context.Context, conn net.Conn) (release func() bool) {
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+
+	return func() bool {
+		if !stop() || ctx.Err() != nil {
+			return false
+		}
+		_ = conn.SetDeadline(time.Time{})
+		return true
+	}
+
+}
+
+// deliver runs a single MAIL/RCPT/DATA transaction on the client.
+// Recipients refused with an SMTP reply are collected and skipped; other errors abort the transaction.
+func deliver(client *smtp.Client, from string, to []string, message []byte) (map[string]error, error) {
+
+	if err := client.Mail(from); err != nil {
+		return nil, fmt.Errorf("MAIL FROM failed: %w", err)
+	}
+
+	rejected := make(map[string]error)
+
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			var reply *textproto.Error
+			if !errors.As(err, &reply) {
+				return nil, fmt.Errorf("RCPT TO %s failed: %w", recipient, err)
+			}
+			rejected[recipient] = fmt.Errorf("recipient rejected: %w", err)
+		}
+	}
+
+	if len(rejected) == len(to) {
+		return rejected, errors.New("all recipients were rejected")
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return nil, fmt.Errorf("DATA failed: %w", err)
+	}
+
+	if _, err := w.Write(message); err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("failed to write message: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("message rejected: %w", err)
+	}
+
+	return rejected, nil
+
+}
+
+// get returns a live idle connection from the pool or dials a new one.
+// Expired or broken idle connections are closed and discarded.
+func (t *smtpTransport) get(ctx context.Context) (*smtpConn, error) {
+
+	for {
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		t.mu.Lock()
+		if len(t.idle) == 0 {
+			t.mu.Unlock()
+			break
+		}
+		conn := t.idle[len(t.idle)-1]
+		t.idle = t.idle[:len(t.idle)-1]
+		t.mu.Unlock()
+
+		if time.Since(conn.lastUsed) > t.idleTimeout {
+			t.quit(conn)
+			continue
+		}
+
+		release := guard(ctx, conn.conn)
+		err := conn.client.Noop()
+		if !release() || err != nil {
+			_ = conn.client.Close()
+			continue
+		}
+
+		return conn, nil
+
+	}
+
+	return t.dial(ctx)
+
+}
+
+// put returns a connection to the pool, or closes it if the pool is full or closed.
+func (t *smtpTransport) put(conn *smtpConn) {
+
+	conn.lastUsed = time.Now()
+
+	t.mu.Lock()
+	if !t.closed && len(t.idle) < t.poolSize {
+		t.idle = append(t.idle, conn)
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
+
+	t.quit(conn)
+
+}
+
+// dial opens a new connection, negotiates TLS according to the configured policy and authenticates.
+func (t *smtpTransport) dial(ctx context.Context) (*smtpConn, error) {
+
+	dialer := &net.Dialer{Timeout: t.dialTimeout}
+
+	var conn net.Conn
+	var err error
+
+	if t.tlsMode == smtpTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: t.tlsConfig}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	release := guard(ctx, conn)
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		release()
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+
+	err = t.handshake(client)
+	if !release() && err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &smtpConn{client: client, conn: conn}, nil
+
+}
+
+// handshake upgrades the connection with STARTTLS if the policy asks for it and authenticates.
+// With the "starttls" policy the connection is refused if the server does not offer STARTTLS.
+func (t *smtpTransport) handshake(client *smtp.Client) error {
+
+	if t.tlsMode == smtpTLSStartTLS || t.tlsMode == smtpTLSOpportunistic {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(t.tlsConfig); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		} else if t.tlsMode == smtpTLSStartTLS {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+	}
+
+	if t.auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(t.auth); err != nil {
+				return fmt.Errorf("SMTP authentication failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// close closes all idle connections. Connections in use are closed when they are returned.
+func (t *smtpTransport) close() {
+
+	t.mu.Lock()
+	idle := t.idle
+	t.idle = nil
+	t.closed = true
+	t.mu.Unlock()
+
+	for _, conn := range idle {
+		t.quit(conn)
+	}
+
+}
+
+// quit politely ends the SMTP session, waiting at most the dial timeout for the server.
+func (t *smtpTransport) quit(conn *smtpConn) {
+	_ = conn.conn.SetDeadline(time.Now().Add(t.dialTimeout))
+	_ = conn.client.Quit()
+}
+
+// loginAuth implements the LOGIN authentication mechanism, which net/smtp does not provide.
+type loginAuth struct {
+	username string // account username
+	password string // account password
+	host     string // expected server host
+}
+
+// Start begins LOGIN authentication. Like smtp.PlainAuth, it refuses to send
+// credentials over an unencrypted connection unless the server is on localhost.
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+
+	return "LOGIN", nil, nil
+
+}
+
+// Next answers the server's username and password challenges.
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+
+	if !more {
+		return nil, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN challenge: %q", fromServer)
+	}
+
+}
+
+// isLocalhost reports whether the host is a loopback name or address.
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
@@ -0,0 +1,284 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is a minimal SMTP server that records delivered messages.
+type fakeSMTPServer struct {
+	listener    net.Listener
+	extensions  []string
+	connections atomic.Int32
+
+	mu         sync.Mutex
+	messages   []string
+	logins     []string
+	recipients []string
+}
+
+func newFakeSMTPServer(t *testing.T, extensions ...string) *fakeSMTPServer {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener, extensions: extensions}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.connections.Add(1)
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+
+}
+
+func (s *fakeSMTPServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+
+	defer func() { _ = conn.Close() }()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+
+	for {
+
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch command {
+		case "EHLO":
+			_ = text.PrintfLine("250-localhost")
+			for _, ext := range s.extensions {
+				_ = text.PrintfLine("250-%s", ext)
+			}
+			_ = text.PrintfLine("250 8BITMIME")
+		case "AUTH":
+			s.login(text)
+		case "RCPT":
+			if strings.Contains(line, "bounce") {
+				_ = text.PrintfLine("550 no such user")
+				continue
+			}
+			s.mu.Lock()
+			s.recipients = append(s.recipients, line)
+			s.mu.Unlock()
+			_ = text.PrintfLine("250 OK")
+		case "MAIL", "RSET", "NOOP":
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			body, err := bufio.NewReader(text.DotReader()).ReadString(0)
+			if err != nil && body == "" {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, body)
+			s.mu.Unlock()
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 not implemented")
+		}
+
+	}
+
+}
+
+func (s *fakeSMTPServer) login(text *textproto.Conn) {
+
+	var credentials []string
+
+	for _, challenge := range []string{"Username:", "Password:"} {
+		_ = text.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(challenge)))
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(line)
+		credentials = append(credentials, string(decoded))
+	}
+
+	s.mu.Lock()
+	s.logins = append(s.logins, strings.Join(credentials, ":"))
+	s.mu.Unlock()
+
+	_ = text.PrintfLine("235 authenticated")
+
+}
+
+func TestSMTPTransport_ReusesConnection(t *testing.T) {
+
+	server := newFakeSMTPServer(t, "AUTH LOGIN")
+
+	transport, err := newSMTPTransport(server.addr(), "localhost", "neo@matrix.com", "0451",
+		config.SMTP{TLS: smtpTLSOpportunistic, Auth: smtpAuthLogin})
+	require.NoError(t, err)
+	defer transport.close()
+
+	for range 3 {
+		_, err := transport.send(context.Background(), "neo@matrix.com", []string{"trinity@matrix.com"}, []byte("Subject: hi\r\n\r\nwake up\r\n"))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), server.connections.Load())
+	assert.Len(t, server.messages, 3)
+	assert.Equal(t, []string{"neo@matrix.com:0451"}, server.logins)
+	assert.Contains(t, server.messages[0], "wake up")
+
+}
+
+func TestSMTPTransport_IdleTimeout(t *testing.T) {
+
+	server := newFakeSMTPServer(t)
+
+	transport, err := newSMTPTransport(server.addr(), "localhost", "", "",
+		config.SMTP{TLS: smtpTLSNone, Auth: smtpAuthNone, IdleTimeout: time.Nanosecond})
+	require.NoError(t, err)
+	defer transport.close()
+
+	for range 2 {
+		_, err := transport.send(context.Background(), "neo@matrix.com", []string{"trinity@matrix.com"}, []byte("\r\nbody\r\n"))
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.Equal(t, int32(2), server.connections.Load())
+
+}
+
+func TestSMTPTransport_RequiredSTARTTLS(t *testing.T) {
+
+	server := newFakeSMTPServer(t)
+
+	transport, err := newSMTPTransport(server.addr(), "localhost", "", "",
+		config.SMTP{TLS: smtpTLSStartTLS, Auth: smtpAuthNone})
+	require.NoError(t, err)
+	defer transport.close()
+
+	_, err = transport.send(context.Background(), "neo@matrix.com", []string{"trinity@matrix.com"}, []byte("\r\nbody\r\n"))
+	require.ErrorContains(t, err, "does not support STARTTLS")
+	assert.Empty(t, server.messages)
+
+}
+
+func TestSMTPTransport_ContextTimeout(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	go func() {
+		conn, err := listener.Accept() // accept, but never send the greeting
+		if err == nil {
+			defer func() { _ = conn.Close() }()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	transport, err := newSMTPTransport(listener.Addr().String(), "localhost", "", "",
+		config.SMTP{TLS: smtpTLSNone, Auth: smtpAuthNone})
+	require.NoError(t, err)
+	defer transport.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = transport.send(ctx, "neo@matrix.com", []string{"trinity@matrix.com"}, []byte("\r\nbody\r\n"))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+}
+
+func TestEmail_SendReportsRejectedRecipients(t *testing.T) {
+
+	server := newFakeSMTPServer(t)
+
+	email, err := NewEmail(config.Notifier{
+		EmailSender:   "chronos@matrix.com",
+		EmailSMTP:     "localhost",
+		EmailSMTPAddr: server.addr(),
+		SMTP:          config.SMTP{TLS: smtpTLSNone, Auth: smtpAuthNone},
+	})
+	require.NoError(t, err)
+	defer email.Close()
+
+	notification := models.Notification{
+		ID:      "id",
+		Subject: "subject",
+		Message: "message",
+		SendTo:  []string{"Neo <neo@matrix.com>", "bounce@matrix.com"},
+		CC:      []string{"trinity@matrix.com"},
+		Recipients: []models.Recipient{
+			{Address: "trinity@matrix.com", Kind: models.RecipientCC, Status: models.StatusSent},
+		},
+	}
+
+	err = email.Send(context.Background(), notification)
+
+	failed, ok := notifier.FailedRecipients(err)
+	require.True(t, ok)
+	assert.Len(t, failed, 1)
+	assert.Contains(t, failed, "bounce@matrix.com")
+	assert.Len(t, server.messages, 1)
+	assert.Equal(t, []string{"RCPT TO:<neo@matrix.com>"}, server.recipients)
+
+	notification.SendTo = []string{"bounce@matrix.com"}
+	notification.CC = nil
+	err = email.Send(context.Background(), notification)
+
+	failed, ok = notifier.FailedRecipients(err)
+	require.True(t, ok)
+	assert.Contains(t, failed, "bounce@matrix.com")
+	assert.Len(t, server.messages, 1)
+
+}
+
+func TestNewSMTPTransport_InvalidConfig(t *testing.T) {
+
+	_, err := newSMTPTransport("localhost:25", "", "", "", config.SMTP{TLS: "sometimes"})
+	require.ErrorContains(t, err, "unknown SMTP TLS mode")
+
+	_, err = newSMTPTransport("localhost:25", "", "", "", config.SMTP{Auth: "xoauth2"})
+	require.ErrorContains(t, err, "unknown SMTP auth mechanism")
+
+	_, err = newSMTPTransport("localhost:25", "", "", "", config.SMTP{CAFile: "/nonexistent/ca.pem"})
+	require.ErrorContains(t, err, "failed to read SMTP CA file")
+
+	transport, err := newSMTPTransport("smtp.matrix.com:465", "", "", "", config.SMTP{TLS: "IMPLICIT", Auth: "CRAM-MD5"})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.matrix.com", transport.host)
+	assert.Equal(t, smtpTLSImplicit, transport.tlsMode)
+
+}
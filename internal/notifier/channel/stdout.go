@@ -0,0 +1,39 @@
+package channel
+
+import (
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"context"
+	"fmt"
+)
+
+// Stdout prints notifications to the standard output.
+type Stdout struct{}
+
+// NewStdout creates a new Stdout channel.
+func NewStdout() *Stdout {
+	return &Stdout{}
+}
+
+// Name returns the channel name.
+func (s *Stdout) Name() string {
+	return models.Stdout
+}
+
+// Capabilities reports that stdout needs no recipients and ignores the subject.
+func (s *Stdout) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{MaxMessageLength: models.MaxMessageLength}
+}
+
+// Validate accepts any notification; stdout has no channel-specific fields.
+func (s *Stdout) Validate(notification models.Notification) error {
+	return nil
+}
+
+// Send prints the notification message to stdout.
+func (s *Stdout) Send(ctx context.Context, notification models.Notification) error {
+	if _, err := fmt.Println(notification.Message); err != nil {
+		return fmt.Errorf("failed to print notification: %w", err)
+	}
+	return nil
+}
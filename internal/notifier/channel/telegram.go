@@ -0,0 +1,204 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	telegramAPI         = "https://api.telegram.org"
+	threadSeparator     = "/"
+	minUsernameLength   = 5
+	maxUsernameLength   = 32
+	telegramMaxChatSize = 64
+)
+
+// Telegram sends notifications via the Telegram bot API.
+// Chats are taken from send_to; the configured receiver is used only when send_to is empty.
+type Telegram struct {
+	token    string       // Telegram bot token
+	receiver string       // default Telegram chat ID used when send_to is empty
+	apiURL   string       // base URL of the Telegram bot API
+	client   *http.Client // HTTP client used for API calls; timeouts come from the send context
+}
+
+// telegramChat is a parsed send_to entry.
+type telegramChat struct {
+	chatID   string // numeric chat ID or @channel username
+	threadID string // optional message_thread_id of a forum topic
+}
+
+// NewTelegram creates a new Telegram channel based on the configuration.
+func NewTelegram(config config.Notifier) *Telegram {
+	return &Telegram{
+		token:    config.TelegramToken,
+		receiver: config.TelegramReceiver,
+		apiURL:   telegramAPI,
+		client:   new(http.Client),
+	}
+}
+
+// Name returns the channel name.
+func (t *Telegram) Name() string {
+	return models.Telegram
+}
+
+// Capabilities reports that Telegram ignores the subject. Recipients are
+// required only if no default receiver is configured.
+func (t *Telegram) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{
+		RequiresRecipients: t.receiver == "",
+		MaxMessageLength:   models.MaxMessageLength,
+	}
+}
+
+// Validate checks that every recipient is a valid chat reference:
+// a numeric chat ID or an @username, optionally followed by "/<message_thread_id>".
+func (t *Telegram) Validate(notification models.Notification) error {
+	for _, recipient := range notification.SendTo {
+		if _, err := parseChat(recipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseChat parses a send_to entry such as "-1001234567890", "@channel" or "-1001234567890/42".
+func parseChat(recipient string) (telegramChat, error) {
+
+	if recipient == "" || len(recipient) > telegramMaxChatSize {
+		return telegramChat{}, errs.ErrInvalidTelegramChat
+	}
+
+	chatID, threadID, hasThread := strings.Cut(recipient, threadSeparator)
+
+	if hasThread {
+		thread, err := strconv.ParseInt(threadID, 10, 64)
+		if err != nil || thread <= 0 {
+			return telegramChat{}, errs.ErrInvalidTelegramChat
+		}
+	}
+
+	if strings.HasPrefix(chatID, "@") {
+		if !validUsername(chatID[1:]) {
+			return telegramChat{}, errs.ErrInvalidTelegramChat
+		}
+	} else if id, err := strconv.ParseInt(chatID, 10, 64); err != nil || id == 0 {
+		return telegramChat{}, errs.ErrInvalidTelegramChat
+	}
+
+	return telegramChat{chatID: chatID, threadID: threadID}, nil
+
+}
+
+// validUsername reports whether name is a valid Telegram username:
+// 5 to 32 characters, letters, digits and underscores, starting with a letter.
+func validUsername(name string) bool {
+
+	if len(name) < minUsernameLength || len(name) > maxUsernameLength {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '_'):
+		default:
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// Send delivers the notification message to every chat in send_to,
+// or to the configured receiver if send_to is empty. Chats that already received
+// the notification are skipped. Failed chats are reported as notifier.RecipientErrors.
+func (t *Telegram) Send(ctx context.Context, notification models.Notification) error {
+
+	if len(notification.SendTo) == 0 {
+		chat, err := parseChat(t.receiver)
+		if err != nil {
+			return fmt.Errorf("invalid default chat %q: %w", t.receiver, err)
+		}
+		return t.sendMessage(ctx, chat, notification.Message)
+	}
+
+	failed := make(notifier.RecipientErrors)
+
+	for _, recipient := range notification.SendTo {
+
+		if notification.Delivered(recipient) {
+			continue
+		}
+
+		chat, err := parseChat(recipient)
+		if err != nil {
+			failed[recipient] = fmt.Errorf("invalid chat: %w", err)
+			continue
+		}
+
+		if err := t.sendMessage(ctx, chat, notification.Message); err != nil {
+			failed[recipient] = err
+		}
+
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+
+	return nil
+
+}
+
+// sendMessage sends a message to a single chat via Telegram bot API.
+func (t *Telegram) sendMessage(ctx context.Context, chat telegramChat, message string) error {
+
+	apiURL := fmt.Sprintf("%s/bot%s/sendMessage", t.apiURL, t.token)
+
+	data := url.Values{}
+	data.Set("chat_id", chat.chatID)
+	data.Set("text", message)
+	if chat.threadID != "" {
+		data.Set("message_thread_id", chat.threadID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build Telegram API request for chat_id %s: %w", chat.chatID, stripURL(err))
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to POST form to Telegram API for chat_id %s: %w", chat.chatID, stripURL(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram API returned non-OK status %s for chat_id %s", resp.Status, chat.chatID)
+	}
+
+	return nil
+
+}
+
+// stripURL removes the request URL from a url.Error, because it contains the bot token.
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
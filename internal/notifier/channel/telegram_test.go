@@ -0,0 +1,85 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegram_Validate(t *testing.T) {
+
+	telegram := NewTelegram(config.Notifier{})
+
+	valid := []string{"905462210", "-1001234567890", "@chronos_alerts", "-1001234567890/42", "@chronos_alerts/7"}
+	for _, recipient := range valid {
+		t.Run("valid "+recipient, func(t *testing.T) {
+			require.NoError(t, telegram.Validate(models.Notification{SendTo: []string{recipient}}))
+		})
+	}
+
+	invalid := []string{"", "0", "abc", "@abc", "@1chronos", "@chronos-alerts", "-100123/0", "-100123/x", "@chronos_alerts/"}
+	for _, recipient := range invalid {
+		t.Run("invalid "+recipient, func(t *testing.T) {
+			err := telegram.Validate(models.Notification{SendTo: []string{recipient}})
+			require.ErrorIs(t, err, errs.ErrInvalidTelegramChat)
+		})
+	}
+
+}
+
+func TestTelegram_Capabilities(t *testing.T) {
+	assert.True(t, NewTelegram(config.Notifier{}).Capabilities().RequiresRecipients)
+	assert.False(t, NewTelegram(config.Notifier{TelegramReceiver: "905462210"}).Capabilities().RequiresRecipients)
+}
+
+func TestTelegram_Send(t *testing.T) {
+
+	var mu sync.Mutex
+	var chats, threads []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		chats = append(chats, r.PostForm.Get("chat_id"))
+		threads = append(threads, r.PostForm.Get("message_thread_id"))
+		mu.Unlock()
+		if r.PostForm.Get("chat_id") == "@broken_chat" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	telegram := NewTelegram(config.Notifier{TelegramToken: "token", TelegramReceiver: "905462210"})
+	telegram.apiURL = server.URL
+
+	t.Run("delivers to every chat", func(t *testing.T) {
+		chats, threads = nil, nil
+		err := telegram.Send(context.Background(), models.Notification{Message: "hi", SendTo: []string{"-1001234567890/42", "@chronos_alerts"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-1001234567890", "@chronos_alerts"}, chats)
+		assert.Equal(t, []string{"42", ""}, threads)
+	})
+
+	t.Run("falls back to configured receiver", func(t *testing.T) {
+		chats, threads = nil, nil
+		require.NoError(t, telegram.Send(context.Background(), models.Notification{Message: "hi"}))
+		assert.Equal(t, []string{"905462210"}, chats)
+	})
+
+	t.Run("reports failed chats", func(t *testing.T) {
+		chats, threads = nil, nil
+		err := telegram.Send(context.Background(), models.Notification{Message: "hi", SendTo: []string{"@chronos_alerts", "@broken_chat"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "@broken_chat")
+		assert.Len(t, chats, 2)
+	})
+
+}
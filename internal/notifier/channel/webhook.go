@@ -0,0 +1,164 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultSignatureHeader = "X-Chronos-Signature"
+	signaturePrefix        = "sha256="
+)
+
+// Webhook posts notifications as JSON to the URLs listed in send_to.
+// The request body is signed with HMAC-SHA256 using the configured secret.
+type Webhook struct {
+	secret          []byte       // HMAC secret used to sign request bodies
+	signatureHeader string       // header carrying the body signature
+	client          *http.Client // HTTP client used for outbound requests; timeouts come from the send context
+}
+
+// webhookPayload is the JSON body posted to webhook endpoints.
+type webhookPayload struct {
+	ID         string    `json:"id"`         // notification ID
+	Subject    string    `json:"subject"`    // notification subject
+	Message    string    `json:"message"`    // notification message
+	Recipients []string  `json:"recipients"` // webhook URLs the notification is delivered to
+	SendAt     time.Time `json:"send_at"`    // scheduled UTC send time
+}
+
+// NewWebhook creates a new Webhook channel based on the configuration.
+// Returns an error if no secret is configured, since every payload must be signed.
+func NewWebhook(config config.Notifier) (*Webhook, error) {
+
+	if config.WebhookSecret == "" {
+		return nil, errors.New("webhook secret is required to sign payloads, set WEBHOOK_SECRET")
+	}
+
+	header := config.WebhookSignatureHeader
+	if header == "" {
+		header = defaultSignatureHeader
+	}
+
+	return &Webhook{
+		secret:          []byte(config.WebhookSecret),
+		signatureHeader: header,
+		client:          new(http.Client),
+	}, nil
+
+}
+
+// Name returns the channel name.
+func (w *Webhook) Name() string {
+	return models.Webhook
+}
+
+// Capabilities reports that webhooks require at least one URL and pass the subject through.
+func (w *Webhook) Capabilities() notifier.Capabilities {
+	return notifier.Capabilities{
+		Subject:            true,
+		RequiresRecipients: true,
+		MaxMessageLength:   models.MaxMessageLength,
+	}
+}
+
+// Validate checks that every recipient is an absolute http or https URL.
+func (w *Webhook) Validate(notification models.Notification) error {
+
+	for _, recipient := range notification.SendTo {
+
+		if len(recipient) > models.MaxURLLength {
+			return errs.ErrRecipientTooLong
+		}
+
+		u, err := url.Parse(recipient)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errs.ErrInvalidWebhookURL
+		}
+
+	}
+
+	return nil
+
+}
+
+// Send posts the signed notification payload to every URL in send_to.
+// A non-2xx response is treated as a failure. URLs that already received the
+// notification are skipped; failed URLs are reported as notifier.RecipientErrors.
+func (w *Webhook) Send(ctx context.Context, notification models.Notification) error {
+
+	body, err := json.Marshal(webhookPayload{
+		ID:         notification.ID,
+		Subject:    notification.Subject,
+		Message:    notification.Message,
+		Recipients: notification.SendTo,
+		SendAt:     notification.SendAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	signature := w.sign(body)
+
+	failed := make(notifier.RecipientErrors)
+	for _, endpoint := range notification.SendTo {
+		if notification.Delivered(endpoint) {
+			continue
+		}
+		if err := w.post(ctx, endpoint, body, signature); err != nil {
+			failed[endpoint] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+
+	return nil
+
+}
+
+// post sends a single signed request to the endpoint.
+func (w *Webhook) post(ctx context.Context, endpoint string, body []byte, signature string) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request for %s: %w", endpoint, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(w.signatureHeader, signature)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to POST to webhook %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned non-2xx status %s", endpoint, resp.Status)
+	}
+
+	return nil
+
+}
+
+// sign returns the hex-encoded HMAC-SHA256 signature of the body prefixed with "sha256=".
+func (w *Webhook) sign(body []byte) string {
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,113 @@
+package channel
+
+import (
+	"Chronos/internal/config"
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Send(t *testing.T) {
+
+	secret := "aboba-secret"
+
+	var gotBody []byte
+	var gotSignature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(defaultSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhook(config.Notifier{WebhookSecret: secret})
+	require.NoError(t, err)
+
+	notification := models.Notification{
+		ID:      "00000000-0000-0000-0000-000000000001",
+		Channel: models.Webhook,
+		Subject: "subject",
+		Message: "hello",
+		SendAt:  time.Now().UTC().Truncate(time.Second),
+		SendTo:  []string{server.URL},
+	}
+
+	require.NoError(t, webhook.Send(context.Background(), notification))
+
+	var payload webhookPayload
+	require.NoError(t, json.Unmarshal(gotBody, &payload))
+	assert.Equal(t, notification.ID, payload.ID)
+	assert.Equal(t, notification.Subject, payload.Subject)
+	assert.Equal(t, notification.Message, payload.Message)
+	assert.Equal(t, notification.SendTo, payload.Recipients)
+	assert.True(t, notification.SendAt.Equal(payload.SendAt))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(gotBody)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), gotSignature)
+
+}
+
+func TestWebhook_Send_Non2xx(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhook(config.Notifier{WebhookSecret: "aboba-secret"})
+	require.NoError(t, err)
+
+	err = webhook.Send(context.Background(), models.Notification{Channel: models.Webhook, SendTo: []string{server.URL}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+
+}
+
+func TestNewWebhook_MissingSecret(t *testing.T) {
+
+	_, err := NewWebhook(config.Notifier{})
+	require.Error(t, err)
+
+}
+
+func TestWebhook_Validate(t *testing.T) {
+
+	webhook, err := NewWebhook(config.Notifier{WebhookSecret: "aboba-secret"})
+	require.NoError(t, err)
+
+	t.Run("valid url", func(t *testing.T) {
+		err := webhook.Validate(models.Notification{SendTo: []string{"https://hooks.example.com/chronos"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		err := webhook.Validate(models.Notification{SendTo: []string{"ftp://hooks.example.com"}})
+		require.ErrorIs(t, err, errs.ErrInvalidWebhookURL)
+	})
+
+	t.Run("relative url", func(t *testing.T) {
+		err := webhook.Validate(models.Notification{SendTo: []string{"/chronos"}})
+		require.ErrorIs(t, err, errs.ErrInvalidWebhookURL)
+	})
+
+	t.Run("url too long", func(t *testing.T) {
+		err := webhook.Validate(models.Notification{SendTo: []string{"https://example.com/" + strings.Repeat("a", models.MaxURLLength)}})
+		require.ErrorIs(t, err, errs.ErrRecipientTooLong)
+	})
+
+}
@@ -0,0 +1,236 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notifier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notifier.go -destination=mocks/mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Chronos/internal/models"
+	notifier "Chronos/internal/notifier"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Channel mocks base method.
+func (m *MockNotifier) Channel(name string) (notifier.Channel, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Channel", name)
+	ret0, _ := ret[0].(notifier.Channel)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Channel indicates an expected call of Channel.
+func (mr *MockNotifierMockRecorder) Channel(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Channel", reflect.TypeOf((*MockNotifier)(nil).Channel), name)
+}
+
+// Close mocks base method.
+func (m *MockNotifier) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockNotifierMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockNotifier)(nil).Close))
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, notification)
+}
+
+// MockChannel is a mock of Channel interface.
+type MockChannel struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelMockRecorder
+	isgomock struct{}
+}
+
+// MockChannelMockRecorder is the mock recorder for MockChannel.
+type MockChannelMockRecorder struct {
+	mock *MockChannel
+}
+
+// NewMockChannel creates a new mock instance.
+func NewMockChannel(ctrl *gomock.Controller) *MockChannel {
+	mock := &MockChannel{ctrl: ctrl}
+	mock.recorder = &MockChannelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannel) EXPECT() *MockChannelMockRecorder {
+	return m.recorder
+}
+
+// Capabilities mocks base method.
+func (m *MockChannel) Capabilities() notifier.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(notifier.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockChannelMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockChannel)(nil).Capabilities))
+}
+
+// Name mocks base method.
+func (m *MockChannel) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockChannelMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockChannel)(nil).Name))
+}
+
+// Send mocks base method.
+func (m *MockChannel) Send(ctx context.Context, notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockChannelMockRecorder) Send(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockChannel)(nil).Send), ctx, notification)
+}
+
+// Validate mocks base method.
+func (m *MockChannel) Validate(notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockChannelMockRecorder) Validate(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockChannel)(nil).Validate), notification)
+}
+
+// MockPreparer is a mock of Preparer interface.
+type MockPreparer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreparerMockRecorder
+	isgomock struct{}
+}
+
+// MockPreparerMockRecorder is the mock recorder for MockPreparer.
+type MockPreparerMockRecorder struct {
+	mock *MockPreparer
+}
+
+// NewMockPreparer creates a new mock instance.
+func NewMockPreparer(ctrl *gomock.Controller) *MockPreparer {
+	mock := &MockPreparer{ctrl: ctrl}
+	mock.recorder = &MockPreparerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreparer) EXPECT() *MockPreparerMockRecorder {
+	return m.recorder
+}
+
+// Prepare mocks base method.
+func (m *MockPreparer) Prepare(notification *models.Notification) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Prepare", notification)
+}
+
+// Prepare indicates an expected call of Prepare.
+func (mr *MockPreparerMockRecorder) Prepare(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockPreparer)(nil).Prepare), notification)
+}
+
+// MockCloser is a mock of Closer interface.
+type MockCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockCloserMockRecorder is the mock recorder for MockCloser.
+type MockCloserMockRecorder struct {
+	mock *MockCloser
+}
+
+// NewMockCloser creates a new mock instance.
+func NewMockCloser(ctrl *gomock.Controller) *MockCloser {
+	mock := &MockCloser{ctrl: ctrl}
+	mock.recorder = &MockCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloser) EXPECT() *MockCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockCloser) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCloser)(nil).Close))
+}
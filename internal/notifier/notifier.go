@@ -1,105 +1,106 @@
 // Package notifier provides functionality to send notifications via different channels.
-// Supported channels are Email, Telegram, and Stdout.
+// Channels are pluggable: each one is a separate type registered in a Registry at boot.
 package notifier
 
 import (
 	"Chronos/internal/config"
 	"Chronos/internal/models"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
-	"net/smtp"
-	"net/url"
+	"slices"
 	"strings"
 )
 
 // Notifier defines the interface for sending notifications.
 type Notifier interface {
-	Notify(notification models.Notification) error // Notify sends a notification using the specified channel.
+	Notify(ctx context.Context, notification models.Notification) error // Notify sends a notification using the specified channel.
+	Channel(name string) (Channel, bool)                                // Channel returns the registered channel with the given name.
+	Close()                                                             // Close releases resources held by the registered channels.
 }
 
-// NewNotifier creates a new Notifier instance based on the provided configuration.
-func NewNotifier(config config.Notifier) Notifier {
-	return newSender(config)
+// Channel is a single delivery channel. It sends notifications, validates
+// channel-specific fields such as recipients, and reports its capabilities.
+type Channel interface {
+	Name() string                                                     // Name returns the channel name used in the "channel" field of a notification.
+	Send(ctx context.Context, notification models.Notification) error // Send delivers the notification, aborting when ctx is done.
+	Validate(notification models.Notification) error                  // Validate checks channel-specific fields of a new notification.
+	Capabilities() Capabilities                                       // Capabilities reports what the channel supports and its limits.
 }
 
-// Sender implements the Notifier interface and sends notifications via Email, Telegram, or Stdout.
-type Sender struct {
-	telegramToken    string // Telegram bot token
-	telegramReceiver string // Telegram chat ID to receive messages
-	emailSender      string // Email address to send from
-	emailPassword    string // Password or app-specific password for email account
-	emailSMTP        string // SMTP server host
-	emailSMTPAddr    string // SMTP server address (host:port)
+// Preparer is an optional interface implemented by channels that fill in
+// channel-specific fields, such as an email Message-ID, when a notification is created.
+type Preparer interface {
+	Prepare(notification *models.Notification) // Prepare sets channel-specific fields of a new notification.
 }
 
-// newSender creates a new Sender instance based on the configuration.
-func newSender(config config.Notifier) *Sender {
-	return &Sender{
-		telegramToken:    config.TelegramToken,
-		telegramReceiver: config.TelegramReceiver,
-		emailSender:      config.EmailSender,
-		emailPassword:    config.EmailPassword,
-		emailSMTP:        config.EmailSMTP,
-		emailSMTPAddr:    config.EmailSMTPAddr,
+// Prepare lets the channel a new notification is addressed to fill in channel-specific fields,
+// such as an email Message-ID, if the channel is registered and implements Preparer.
+func Prepare(n Notifier, notification *models.Notification) {
+	if channel, ok := n.Channel(notification.Channel); ok {
+		if preparer, ok := channel.(Preparer); ok {
+			preparer.Prepare(notification)
+		}
 	}
 }
 
-// Notify sends the notification using the appropriate channel.
-// Returns an error if sending fails or if the channel is unsupported.
-func (s *Sender) Notify(notification models.Notification) error {
+// Closer is an optional interface implemented by channels that hold
+// long-lived resources, such as pooled SMTP connections.
+type Closer interface {
+	Close() // Close releases the channel's resources.
+}
 
-	switch strings.ToLower(notification.Channel) {
-	case models.Telegram:
-		if err := s.sendTelegram(notification.Message); err != nil {
-			return fmt.Errorf("unable to send Telegram notification: %w", err)
-		}
-	case models.Email:
-		if err := s.sendEmail(notification.SendTo, notification.Subject, notification.Message); err != nil {
-			return fmt.Errorf("unable to send Email notification: %w", err)
-		}
-	case models.Stdout:
-		if _, err := fmt.Println(notification.Message); err != nil {
-			return fmt.Errorf("unable to print notification to stdout: %w", err)
-		}
-	default:
-		return fmt.Errorf("unsupported notification channel: %s", notification.Channel)
-	}
+// Capabilities describes what a channel supports and which limits apply to it.
+// Generic checks based on these values are performed by the service layer
+// before the channel's own Validate is called.
+type Capabilities struct {
+	Subject            bool // Subject reports whether the channel uses the notification subject
+	RequiresRecipients bool // RequiresRecipients reports whether send_to must be non-empty
+	MaxRecipients      int  // MaxRecipients is the maximum number of recipients, 0 means unlimited
+	MaxMessageLength   int  // MaxMessageLength is the maximum message length in runes
+}
 
-	return nil
+// RecipientErrors is returned by Send when delivery failed for some recipients.
+// It maps each failed recipient, exactly as listed in the notification, to its error.
+// Recipients that are not in the map were delivered successfully.
+type RecipientErrors map[string]error
 
-}
+// Error lists the failed recipients and their errors in a stable order.
+func (e RecipientErrors) Error() string {
 
-// sendEmail sends an email to the specified recipients using SMTP.
-func (s *Sender) sendEmail(sendTo []string, subject string, body string) error {
-	auth := smtp.PlainAuth("", s.emailSender, s.emailPassword, s.emailSMTP)
-	message := []byte("Subject: " + subject + "\n" + body)
-	if err := smtp.SendMail(s.emailSMTPAddr, auth, s.emailSender, sendTo, message); err != nil {
-		return fmt.Errorf("failed to send email to %v via SMTP server %s: %w", sendTo, s.emailSMTPAddr, err)
+	recipients := make([]string, 0, len(e))
+	for recipient := range e {
+		recipients = append(recipients, recipient)
 	}
-	return nil
-}
-
-// sendTelegram sends a message via Telegram bot API.
-func (s *Sender) sendTelegram(message string) error {
+	slices.Sort(recipients)
 
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.telegramToken)
+	msgs := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", recipient, e[recipient]))
+	}
 
-	data := url.Values{}
-	data.Set("chat_id", s.telegramReceiver)
-	data.Set("text", message)
+	return fmt.Sprintf("delivery failed for %d recipient(s): %s", len(e), strings.Join(msgs, "; "))
 
-	client := new(http.Client)
+}
 
-	resp, err := client.PostForm(apiURL, data)
-	if err != nil {
-		return fmt.Errorf("failed to POST form to Telegram API %s: %w", apiURL, err)
+// FailedRecipients extracts RecipientErrors from err.
+// The second result is false if err does not report per-recipient failures.
+func FailedRecipients(err error) (RecipientErrors, bool) {
+	var failed RecipientErrors
+	if errors.As(err, &failed) {
+		return failed, true
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return nil, false
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("telegram API returned non-OK status %s for chat_id %s", resp.Status, s.telegramReceiver)
+// NewNotifier creates a new Notifier instance with the given channels registered.
+// Send timeouts are taken from the configuration.
+func NewNotifier(config config.Notifier, channels ...Channel) (Notifier, error) {
+	registry := NewRegistry(config.DefaultTimeout, config.Timeouts)
+	for _, channel := range channels {
+		if err := registry.Register(channel); err != nil {
+			return nil, err
+		}
 	}
-
-	return nil
-
+	return registry, nil
 }
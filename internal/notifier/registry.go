@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultSendTimeout is used for channels without a configured timeout
+// when no default timeout is configured either.
+const DefaultSendTimeout = 30 * time.Second
+
+// Registry implements the Notifier interface by dispatching notifications
+// to the channel registered under the notification's channel name.
+// Channels are registered once at boot; the registry is read-only afterwards
+// and is therefore safe for concurrent use by consumer workers.
+type Registry struct {
+	channels       map[string]Channel       // registered channels keyed by lower-cased name
+	timeouts       map[string]time.Duration // per-channel send timeouts keyed by lower-cased name
+	defaultTimeout time.Duration            // send timeout for channels without their own timeout
+}
+
+// NewRegistry creates an empty channel registry with the given send timeouts.
+// If defaultTimeout is not positive, DefaultSendTimeout is used.
+func NewRegistry(defaultTimeout time.Duration, timeouts map[string]time.Duration) *Registry {
+
+	if defaultTimeout <= 0 {
+		defaultTimeout = DefaultSendTimeout
+	}
+
+	registry := &Registry{
+		channels:       make(map[string]Channel),
+		timeouts:       make(map[string]time.Duration, len(timeouts)),
+		defaultTimeout: defaultTimeout,
+	}
+
+	for name, timeout := range timeouts {
+		registry.timeouts[strings.ToLower(name)] = timeout
+	}
+
+	return registry
+
+}
+
+// Register adds a channel to the registry.
+// Returns an error if the channel has no name or a channel with the same name is already registered.
+func (r *Registry) Register(channel Channel) error {
+
+	name := strings.ToLower(channel.Name())
+	if name == "" {
+		return fmt.Errorf("channel name is empty")
+	}
+
+	if _, exists := r.channels[name]; exists {
+		return fmt.Errorf("channel %s is already registered", name)
+	}
+
+	r.channels[name] = channel
+
+	return nil
+
+}
+
+// Channel returns the registered channel with the given name. The lookup is case-insensitive.
+func (r *Registry) Channel(name string) (Channel, bool) {
+	channel, ok := r.channels[strings.ToLower(name)]
+	return channel, ok
+}
+
+// Notify sends the notification using the channel it is addressed to.
+// The send is bounded by the channel's timeout and aborted if ctx is cancelled.
+// Returns an error if sending fails or if the channel is not registered.
+func (r *Registry) Notify(ctx context.Context, notification models.Notification) error {
+
+	channel, ok := r.Channel(notification.Channel)
+	if !ok {
+		return fmt.Errorf("unsupported notification channel: %s", notification.Channel)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout(channel.Name()))
+	defer cancel()
+
+	if err := channel.Send(ctx, notification); err != nil {
+		return fmt.Errorf("unable to send %s notification: %w", channel.Name(), err)
+	}
+
+	return nil
+
+}
+
+// Timeout returns the send timeout of the channel with the given name.
+func (r *Registry) Timeout(name string) time.Duration {
+	if timeout, ok := r.timeouts[strings.ToLower(name)]; ok && timeout > 0 {
+		return timeout
+	}
+	return r.defaultTimeout
+}
+
+// Close closes every registered channel that implements Closer.
+func (r *Registry) Close() {
+	for _, channel := range r.channels {
+		if closer, ok := channel.(Closer); ok {
+			closer.Close()
+		}
+	}
+}
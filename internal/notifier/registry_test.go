@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingChannel struct{ name string }
+
+func (c blockingChannel) Name() string                       { return c.name }
+func (c blockingChannel) Validate(models.Notification) error { return nil }
+func (c blockingChannel) Capabilities() Capabilities         { return Capabilities{} }
+func (c blockingChannel) Send(ctx context.Context, _ models.Notification) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+
+	registry := NewRegistry(0, map[string]time.Duration{"Telegram": 5 * time.Second, "email": 0})
+
+	assert.Equal(t, 5*time.Second, registry.Timeout("telegram"))
+	assert.Equal(t, DefaultSendTimeout, registry.Timeout("email"))
+	assert.Equal(t, DefaultSendTimeout, registry.Timeout("stdout"))
+
+}
+
+func TestRegistry_NotifyHonorsTimeoutAndCancellation(t *testing.T) {
+
+	registry := NewRegistry(time.Minute, map[string]time.Duration{"slow": 20 * time.Millisecond})
+	require.NoError(t, registry.Register(blockingChannel{name: "slow"}))
+	require.NoError(t, registry.Register(blockingChannel{name: "hung"}))
+
+	err := registry.Notify(context.Background(), models.Notification{Channel: "slow"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = registry.Notify(ctx, models.Notification{Channel: "hung"})
+	require.ErrorIs(t, err, context.Canceled)
+
+}
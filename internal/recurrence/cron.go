@@ -0,0 +1,162 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bounds describes the allowed values of a single cron field.
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes  = bounds{min: 0, max: 59}
+	hours    = bounds{min: 0, max: 23}
+	monthDay = bounds{min: 1, max: 31}
+	months   = bounds{min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	weekDays = bounds{min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// macros maps the predefined cron schedules to their five-field equivalents.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses a standard five-field cron expression (minute, hour, day of month,
+// month, day of week) or one of the predefined macros such as @daily.
+// Fields support lists, ranges, steps, and month and weekday names; 7 is accepted as Sunday.
+// As in classic cron, if both day fields are restricted a day matches either of them.
+func parseCron(expr string) (fields, error) {
+
+	if macro, ok := macros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return fields{}, fmt.Errorf("expected 5 fields, got %d", len(parts))
+	}
+
+	var f fields
+	var err error
+
+	if f.minute, err = parseField(parts[0], minutes); err != nil {
+		return fields{}, fmt.Errorf("minute: %w", err)
+	}
+	if f.hour, err = parseField(parts[1], hours); err != nil {
+		return fields{}, fmt.Errorf("hour: %w", err)
+	}
+	if f.dom, err = parseField(parts[2], monthDay); err != nil {
+		return fields{}, fmt.Errorf("day of month: %w", err)
+	}
+	if f.month, err = parseField(parts[3], months); err != nil {
+		return fields{}, fmt.Errorf("month: %w", err)
+	}
+	if f.dow, err = parseField(parts[4], weekDays); err != nil {
+		return fields{}, fmt.Errorf("day of week: %w", err)
+	}
+
+	if f.dow&(1<<7) != 0 {
+		f.dow = f.dow&^(1<<7) | 1 // 7 is an alias for Sunday
+	}
+
+	f.domAny = isWildcard(parts[2])
+	f.dowAny = isWildcard(parts[4])
+
+	return f, nil
+
+}
+
+// isWildcard reports whether a day field leaves the day unrestricted.
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit set.
+func parseField(field string, b bounds) (uint64, error) {
+
+	var set uint64
+
+	for item := range strings.SplitSeq(field, ",") {
+
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		low, high := b.min, b.max
+
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseValue(lowPart, b); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(highPart, b); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, b)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+
+	}
+
+	return set, nil
+
+}
+
+// parseValue parses a single numeric or named value and checks it against the field bounds.
+func parseValue(value string, b bounds) (int, error) {
+
+	if n, ok := b.names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	if n < b.min || n > b.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, b.min, b.max)
+	}
+
+	return n, nil
+
+}
@@ -0,0 +1,42 @@
+package recurrence
+
+import (
+	"Chronos/internal/models"
+	"time"
+
+	"github.com/wb-go/wbf/helpers"
+)
+
+const localDateTime = "2006-01-02 15:04:05"
+
+// Occurrence creates a new pending notification from the schedule's notification, to be sent at sendAt.
+// Its local send time and time zone are those of the schedule, and its delivery deadline
+// is the schedule's max delay after sendAt.
+func Occurrence(schedule models.Schedule, sendAt time.Time) models.Notification {
+
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	notification := schedule.Notification
+
+	notification.ID = helpers.CreateUUID()
+	notification.ScheduleID = schedule.ID
+	notification.Status = models.StatusPending
+	notification.SendAt = sendAt.UTC()
+	notification.SendAtLocal = sendAt.In(loc).Format(localDateTime)
+	notification.TimeZone = schedule.TimeZone
+	notification.ExpiresAt = nil
+	notification.MessageID = ""
+	notification.Recipients = nil
+	notification.UpdatedAt = time.Now().UTC()
+
+	if maxDelay, err := time.ParseDuration(schedule.MaxDelay); err == nil && maxDelay > 0 {
+		expiresAt := notification.SendAt.Add(maxDelay)
+		notification.ExpiresAt = &expiresAt
+	}
+
+	return notification
+
+}
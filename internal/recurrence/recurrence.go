@@ -0,0 +1,253 @@
+// Package recurrence computes the occurrences of recurring schedules.
+// Schedules are described either by a five-field cron expression or by an RFC 5545 RRULE
+// and are evaluated in their own time zone with minute precision, so a daily 09:00 schedule
+// keeps firing at 09:00 local time across daylight saving time changes. As in classic cron,
+// a time skipped when clocks spring forward fires right after the change, and a time repeated
+// when they fall back fires once.
+package recurrence
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"fmt"
+	"time"
+	_ "time/tzdata" // the release image has no zoneinfo database
+)
+
+// searchHorizon bounds the search for the next occurrence, so rules that can never match,
+// such as February 30th, terminate.
+const searchHorizon = 5 // years
+
+// fields holds the allowed values of every time component as bit sets.
+type fields struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool // cron day fields left unrestricted
+	dayAnd                        bool // both day fields must match, as in RRULE
+}
+
+// Rule is a parsed cron expression or RRULE bound to a time zone.
+type Rule struct {
+	fields
+	loc       *time.Location
+	freq      frequency    // RRULE frequency, none for cron expressions
+	interval  int          // RRULE interval in periods of freq
+	dtstart   time.Time    // RRULE start, periods are counted from it
+	weekStart time.Weekday // RRULE first day of the week
+	count     int          // RRULE maximum number of occurrences, 0 for unlimited
+	until     time.Time    // RRULE last allowed occurrence time, zero for unlimited
+}
+
+// Parse validates the schedule's time zone and parses its cron expression or RRULE.
+// Exactly one of them must be set. The schedule's StartAt is used as the RRULE DTSTART.
+func Parse(schedule models.Schedule) (*Rule, error) {
+
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, errs.ErrInvalidTimeZone
+	}
+
+	switch {
+
+	case schedule.Cron != "" && schedule.RRule != "":
+		return nil, fmt.Errorf("%w: cron and rrule are mutually exclusive", errs.ErrInvalidRecurrence)
+
+	case schedule.Cron != "":
+		f, err := parseCron(schedule.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cron: %v", errs.ErrInvalidRecurrence, err)
+		}
+		return &Rule{fields: f, loc: loc, interval: 1}, nil
+
+	case schedule.RRule != "":
+		r, err := parseRRule(schedule.RRule, schedule.StartAt, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rrule: %v", errs.ErrInvalidRecurrence, err)
+		}
+		return r, nil
+
+	default:
+		return nil, fmt.Errorf("%w: either cron or rrule is required", errs.ErrInvalidRecurrence)
+
+	}
+
+}
+
+// Next returns the first occurrence of the schedule strictly after the given time, in UTC.
+// It honors the schedule's start and end times, its maximum number of occurrences and the
+// RRULE COUNT and UNTIL parts. The boolean result is false if the series has ended.
+func Next(schedule models.Schedule, after time.Time) (time.Time, bool, error) {
+
+	rule, err := Parse(schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	limit := rule.count
+	if schedule.MaxOccurrences > 0 && (limit == 0 || schedule.MaxOccurrences < limit) {
+		limit = schedule.MaxOccurrences
+	}
+
+	if limit > 0 && schedule.Occurrences >= limit {
+		return time.Time{}, false, nil
+	}
+
+	if after.Before(schedule.StartAt) {
+		after = schedule.StartAt.Add(-time.Nanosecond)
+	}
+
+	next := rule.Next(after)
+
+	switch {
+	case next.IsZero():
+		return time.Time{}, false, nil
+	case !rule.until.IsZero() && next.After(rule.until):
+		return time.Time{}, false, nil
+	case schedule.EndAt != nil && next.After(*schedule.EndAt):
+		return time.Time{}, false, nil
+	}
+
+	return next.UTC(), true, nil
+
+}
+
+// Next returns the first time strictly after the given one that matches the rule,
+// or the zero time if there is none within the search horizon.
+func (r *Rule) Next(after time.Time) time.Time {
+
+	limit := after.AddDate(searchHorizon, 0, 0)
+
+	for {
+		t := r.fields.next(after, r.loc, limit)
+		if t.IsZero() || r.inInterval(t) {
+			return t
+		}
+		after = t
+	}
+
+}
+
+// next returns the first minute after the given time that matches the fields,
+// or the zero time if none matches before limit. Whenever a component does not
+// match, the search jumps to the start of the next value of that component.
+// Hours are advanced in elapsed time, since the start of an hour skipped by a daylight
+// saving time change may normalize to a time before the current one.
+func (f fields) next(after time.Time, loc *time.Location, limit time.Time) time.Time {
+
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+
+		switch {
+		case !has(f.month, int(t.Month())):
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+		case !f.dayMatches(t):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+		case !has(f.hour, t.Hour()):
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case !has(f.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			if end, ok := f.repeated(t); ok {
+				t = end
+				continue
+			}
+			return f.gap(after.In(loc), t)
+		}
+
+	}
+
+	return time.Time{}
+
+}
+
+// forward returns next if it lies after t, and the following minute otherwise. A local midnight
+// that falls into a daylight saving time gap may normalize to the evening before, which would
+// otherwise make the search jump to the same day again.
+func forward(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Minute)
+}
+
+// repeated reports whether the wall clock time of t already occurred earlier, in the hour that
+// is repeated when clocks fall back, and returns the time the repetition ends. As in classic cron,
+// schedules restricted to certain hours fire only once in it; hourly and more frequent ones are
+// not affected.
+func (f fields) repeated(t time.Time) (time.Time, bool) {
+
+	if f.hour == all(hours) {
+		return time.Time{}, false
+	}
+
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return time.Time{}, false
+	}
+
+	_, offset := t.Zone()
+	_, before := start.Add(-time.Nanosecond).Zone()
+
+	shift := time.Duration(before-offset) * time.Second
+	if shift <= 0 || t.Sub(start) >= shift {
+		return time.Time{}, false
+	}
+
+	return start.Add(shift), true
+
+}
+
+// gap returns the end of the first daylight saving time gap between after and t in which
+// a skipped wall clock time matches the fields, or t if there is none. As in classic cron,
+// a time that does not exist on the day clocks spring forward fires right after the change
+// instead of being skipped.
+func (f fields) gap(after time.Time, t time.Time) time.Time {
+
+	for {
+
+		_, end := after.ZoneBounds()
+		if end.IsZero() || end.After(t) {
+			return t
+		}
+
+		_, before := after.Zone()
+		_, offset := end.Zone()
+
+		// wall clock times skipped by the change, expressed in UTC so that no zone rules apply
+		wall := end.Add(time.Duration(before) * time.Second).UTC()
+		for skipped := time.Duration(0); skipped < time.Duration(offset-before)*time.Second; skipped += time.Minute {
+			if f.matches(wall.Add(skipped)) {
+				return end
+			}
+		}
+
+		after = end
+
+	}
+
+}
+
+// matches reports whether every component of t matches the fields.
+func (f fields) matches(t time.Time) bool {
+	return has(f.month, int(t.Month())) && f.dayMatches(t) && has(f.hour, t.Hour()) && has(f.minute, t.Minute())
+}
+
+// dayMatches applies the day of month and day of week fields to t.
+// Cron matches either restricted field, RRULE requires both.
+func (f fields) dayMatches(t time.Time) bool {
+
+	dom := has(f.dom, t.Day())
+	dow := has(f.dow, int(t.Weekday()))
+
+	if f.dayAnd || f.domAny || f.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+
+}
+
+// has reports whether the bit set contains the value.
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
@@ -0,0 +1,214 @@
+package recurrence
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, value string, zone string) time.Time {
+	loc, err := time.LoadLocation(zone)
+	require.NoError(t, err)
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return parsed
+}
+
+func occurrences(t *testing.T, schedule models.Schedule, after time.Time, n int) []string {
+
+	var result []string
+	loc, _ := time.LoadLocation(schedule.TimeZone)
+
+	for range n {
+		next, ok, err := Next(schedule, after)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		result = append(result, next.In(loc).Format("2006-01-02 15:04 Mon"))
+		schedule.Occurrences++
+		after = next
+	}
+
+	return result
+
+}
+
+func TestNext_Cron(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		cron     string
+		zone     string
+		after    string
+		expected []string
+	}{
+		{"every weekday at 9", "0 9 * * MON-FRI", "Europe/Moscow", "2026-01-09 10:00",
+			[]string{"2026-01-12 09:00 Mon", "2026-01-13 09:00 Tue"}},
+		{"every 15 minutes", "*/15 * * * *", "UTC", "2026-01-09 10:07",
+			[]string{"2026-01-09 10:15 Fri", "2026-01-09 10:30 Fri", "2026-01-09 10:45 Fri"}},
+		{"macro", "@monthly", "UTC", "2026-01-09 10:00",
+			[]string{"2026-02-01 00:00 Sun", "2026-03-01 00:00 Sun"}},
+		{"day of month or weekday", "0 12 13 * 5", "UTC", "2026-02-01 00:00",
+			[]string{"2026-02-06 12:00 Fri", "2026-02-13 12:00 Fri", "2026-02-20 12:00 Fri"}},
+		{"sunday as 7 and month names", "30 8 * jan,feb 7", "UTC", "2026-01-30 00:00",
+			[]string{"2026-02-01 08:30 Sun", "2026-02-08 08:30 Sun"}},
+		{"local time kept across DST", "0 9 * * *", "Europe/Berlin", "2026-03-28 10:00",
+			[]string{"2026-03-29 09:00 Sun", "2026-03-30 09:00 Mon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := models.Schedule{Cron: tt.cron, TimeZone: tt.zone}
+			assert.Equal(t, tt.expected, occurrences(t, schedule, mustTime(t, tt.after, tt.zone), len(tt.expected)))
+		})
+	}
+
+}
+
+func TestNext_RRule(t *testing.T) {
+
+	start := mustTime(t, "2026-01-05 09:30", "Europe/Moscow") // Monday
+
+	tests := []struct {
+		name     string
+		rrule    string
+		expected []string
+	}{
+		{"daily defaults to dtstart time", "FREQ=DAILY",
+			[]string{"2026-01-05 09:30 Mon", "2026-01-06 09:30 Tue"}},
+		{"every other week on two days", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			[]string{"2026-01-05 09:30 Mon", "2026-01-08 09:30 Thu", "2026-01-19 09:30 Mon", "2026-01-22 09:30 Thu"}},
+		{"monthly on the 31st skips short months", "FREQ=MONTHLY;BYMONTHDAY=31;BYHOUR=8;BYMINUTE=0",
+			[]string{"2026-01-31 08:00 Sat", "2026-03-31 08:00 Tue"}},
+		{"count", "FREQ=HOURLY;INTERVAL=6;COUNT=3",
+			[]string{"2026-01-05 09:30 Mon", "2026-01-05 15:30 Mon", "2026-01-05 21:30 Mon"}},
+		{"until", "FREQ=DAILY;UNTIL=20260107",
+			[]string{"2026-01-05 09:30 Mon", "2026-01-06 09:30 Tue", "2026-01-07 09:30 Wed"}},
+		{"yearly", "FREQ=YEARLY",
+			[]string{"2026-01-05 09:30 Mon", "2027-01-05 09:30 Tue"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := models.Schedule{RRule: tt.rrule, TimeZone: "Europe/Moscow", StartAt: start}
+			assert.Equal(t, tt.expected, occurrences(t, schedule, start.Add(-time.Hour), 5)[:len(tt.expected)])
+		})
+	}
+
+	t.Run("count ends the series", func(t *testing.T) {
+		schedule := models.Schedule{RRule: "FREQ=DAILY;COUNT=2", TimeZone: "UTC", StartAt: start}
+		assert.Len(t, occurrences(t, schedule, start.Add(-time.Hour), 5), 2)
+	})
+
+}
+
+func TestNext_Bounds(t *testing.T) {
+
+	after := mustTime(t, "2026-01-01 00:00", "UTC")
+	end := mustTime(t, "2026-01-03 12:00", "UTC")
+
+	schedule := models.Schedule{Cron: "@daily", TimeZone: "UTC", StartAt: mustTime(t, "2026-01-02 00:00", "UTC"), EndAt: &end}
+	assert.Equal(t, []string{"2026-01-02 00:00 Fri", "2026-01-03 00:00 Sat"}, occurrences(t, schedule, after, 5))
+
+	schedule = models.Schedule{Cron: "@daily", TimeZone: "UTC", MaxOccurrences: 3, Occurrences: 2}
+	assert.Len(t, occurrences(t, schedule, after, 5), 1)
+
+	schedule = models.Schedule{Cron: "0 0 30 2 *", TimeZone: "UTC"}
+	_, ok, err := Next(schedule, after)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+}
+
+func TestNext_DST(t *testing.T) {
+
+	start := mustTime(t, "2026-03-06 09:00", "America/New_York")
+
+	tests := []struct {
+		name     string
+		schedule models.Schedule
+		after    string
+		expected []string // instants in UTC
+	}{
+		{"daily across spring forward", models.Schedule{Cron: "0 9 * * *", TimeZone: "America/New_York"}, "2026-03-07 10:00",
+			[]string{"2026-03-08T13:00:00Z", "2026-03-09T13:00:00Z"}},
+		{"skipped time fires after the change", models.Schedule{Cron: "30 2 * * *", TimeZone: "Europe/Berlin"}, "2026-03-28 03:00",
+			[]string{"2026-03-29T01:00:00Z", "2026-03-30T00:30:00Z"}},
+		{"repeated time fires once", models.Schedule{Cron: "30 2 * * *", TimeZone: "Europe/Berlin"}, "2026-10-24 03:00",
+			[]string{"2026-10-25T00:30:00Z", "2026-10-26T01:30:00Z"}},
+		{"hourly keeps firing in the repeated hour", models.Schedule{Cron: "0 * * * *", TimeZone: "Europe/Berlin"}, "2026-10-25 01:30",
+			[]string{"2026-10-25T00:00:00Z", "2026-10-25T01:00:00Z", "2026-10-25T02:00:00Z"}},
+		{"midnight skipped by the change", models.Schedule{Cron: "@daily", TimeZone: "America/Santiago"}, "2026-09-05 12:00",
+			[]string{"2026-09-06T04:00:00Z", "2026-09-07T03:00:00Z"}},
+		{"rrule daily across spring forward", models.Schedule{RRule: "FREQ=DAILY", TimeZone: "America/New_York", StartAt: start}, "2026-03-06 08:00",
+			[]string{"2026-03-06T14:00:00Z", "2026-03-07T14:00:00Z", "2026-03-08T13:00:00Z", "2026-03-09T13:00:00Z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			after := mustTime(t, tt.after, tt.schedule.TimeZone)
+
+			var result []string
+			for range tt.expected {
+				next, ok, err := Next(tt.schedule, after)
+				require.NoError(t, err)
+				require.True(t, ok)
+				result = append(result, next.UTC().Format(time.RFC3339))
+				tt.schedule.Occurrences++
+				after = next
+			}
+
+			assert.Equal(t, tt.expected, result)
+
+		})
+	}
+
+}
+
+func TestParse_Errors(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		schedule models.Schedule
+		err      error
+	}{
+		{"nothing", models.Schedule{}, errs.ErrInvalidRecurrence},
+		{"both", models.Schedule{Cron: "@daily", RRule: "FREQ=DAILY"}, errs.ErrInvalidRecurrence},
+		{"bad zone", models.Schedule{Cron: "@daily", TimeZone: "Mars/Olympus"}, errs.ErrInvalidTimeZone},
+		{"too few fields", models.Schedule{Cron: "0 9 * *"}, errs.ErrInvalidRecurrence},
+		{"out of range", models.Schedule{Cron: "60 9 * * *"}, errs.ErrInvalidRecurrence},
+		{"bad step", models.Schedule{Cron: "*/0 9 * * *"}, errs.ErrInvalidRecurrence},
+		{"missing freq", models.Schedule{RRule: "INTERVAL=2"}, errs.ErrInvalidRecurrence},
+		{"ordinal day", models.Schedule{RRule: "FREQ=MONTHLY;BYDAY=1MO"}, errs.ErrInvalidRecurrence},
+		{"unsupported part", models.Schedule{RRule: "FREQ=YEARLY;BYWEEKNO=20"}, errs.ErrInvalidRecurrence},
+		{"count and until", models.Schedule{RRule: "FREQ=DAILY;COUNT=2;UNTIL=20260101"}, errs.ErrInvalidRecurrence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.schedule)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+}
+
+func TestOccurrence_MaxDelay(t *testing.T) {
+
+	sendAt := mustTime(t, "2030-01-01 09:00", "Europe/Moscow")
+
+	occurrence := Occurrence(models.Schedule{TimeZone: "Europe/Moscow", MaxDelay: "15m0s"}, sendAt)
+	require.NotNil(t, occurrence.ExpiresAt)
+	assert.True(t, occurrence.ExpiresAt.Equal(sendAt.Add(15*time.Minute)))
+	assert.Equal(t, "2030-01-01 09:00:00", occurrence.SendAtLocal)
+
+	occurrence = Occurrence(models.Schedule{TimeZone: "Europe/Moscow"}, sendAt)
+	assert.Nil(t, occurrence.ExpiresAt)
+
+}
@@ -0,0 +1,253 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frequency is the FREQ part of an RRULE.
+type frequency int
+
+const (
+	none frequency = iota // cron expressions have no frequency
+	minutely
+	hourly
+	daily
+	weekly
+	monthly
+	yearly
+)
+
+var frequencies = map[string]frequency{
+	"MINUTELY": minutely,
+	"HOURLY":   hourly,
+	"DAILY":    daily,
+	"WEEKLY":   weekly,
+	"MONTHLY":  monthly,
+	"YEARLY":   yearly,
+}
+
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// untilLayouts are the accepted formats of the UNTIL part, from most to least precise.
+var untilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// parseRRule parses the subset of RFC 5545 recurrence rules supported by Chronos:
+// FREQ (MINUTELY to YEARLY), INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY (positive days only),
+// BYDAY (weekdays without ordinals), BYHOUR, BYMINUTE and WKST. Parts that are not set are
+// derived from dtstart as the RFC prescribes, e.g. FREQ=DAILY repeats at the time of dtstart.
+func parseRRule(text string, dtstart time.Time, loc *time.Location) (*Rule, error) {
+
+	r := &Rule{loc: loc, interval: 1, dtstart: dtstart.In(loc).Truncate(time.Minute), weekStart: time.Monday}
+	r.dayAnd = true
+
+	var byMonth, byMonthDay, byDay, byHour, byMinute string
+
+	text = strings.TrimPrefix(strings.TrimSpace(text), "RRULE:")
+
+	for part := range strings.SplitSeq(text, ";") {
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid part %q", part)
+		}
+
+		var err error
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			if r.freq, ok = frequencies[strings.ToUpper(value)]; !ok {
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			if r.interval, err = strconv.Atoi(value); err != nil || r.interval <= 0 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+		case "COUNT":
+			if r.count, err = strconv.Atoi(value); err != nil || r.count <= 0 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+		case "UNTIL":
+			if r.until, err = parseUntil(value, loc); err != nil {
+				return nil, err
+			}
+		case "WKST":
+			if r.weekStart, ok = rruleDays[strings.ToUpper(value)]; !ok {
+				return nil, fmt.Errorf("invalid WKST %q", value)
+			}
+		case "BYMONTH":
+			byMonth = value
+		case "BYMONTHDAY":
+			byMonthDay = value
+		case "BYDAY":
+			byDay = value
+		case "BYHOUR":
+			byHour = value
+		case "BYMINUTE":
+			byMinute = value
+		default:
+			return nil, fmt.Errorf("unsupported part %q", key)
+		}
+
+	}
+
+	if r.freq == none {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+
+	if r.count > 0 && !r.until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL are mutually exclusive")
+	}
+
+	if err := r.byRules(byMonth, byMonthDay, byDay, byHour, byMinute); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+
+}
+
+// byRules fills the rule's fields from the BY* parts, defaulting the missing ones to dtstart.
+func (r *Rule) byRules(byMonth, byMonthDay, byDay, byHour, byMinute string) error {
+
+	var err error
+	start := r.dtstart
+
+	r.minute, err = byList(byMinute, minutes, r.freq > minutely, start.Minute())
+	if err != nil {
+		return fmt.Errorf("BYMINUTE: %w", err)
+	}
+
+	r.hour, err = byList(byHour, hours, r.freq > hourly, start.Hour())
+	if err != nil {
+		return fmt.Errorf("BYHOUR: %w", err)
+	}
+
+	noDays := byDay == "" && byMonthDay == ""
+
+	r.month, err = byList(byMonth, months, r.freq == yearly && noDays, int(start.Month()))
+	if err != nil {
+		return fmt.Errorf("BYMONTH: %w", err)
+	}
+
+	r.dom, err = byList(byMonthDay, monthDay, noDays && r.freq >= monthly, start.Day())
+	if err != nil {
+		return fmt.Errorf("BYMONTHDAY: %w", err)
+	}
+
+	r.dow = all(weekDays)
+	if byDay != "" {
+		if r.dow, err = parseDays(byDay); err != nil {
+			return fmt.Errorf("BYDAY: %w", err)
+		}
+	} else if r.freq == weekly {
+		r.dow = 1 << uint(start.Weekday())
+	}
+
+	return nil
+
+}
+
+// byList parses a comma-separated BY* list. If the list is empty, it matches
+// only the dtstart value when useStart is set, and every value otherwise.
+func byList(list string, b bounds, useStart bool, start int) (uint64, error) {
+
+	if list != "" {
+		return parseField(list, bounds{min: b.min, max: b.max}) // RRULE lists are numeric only
+	}
+
+	if useStart {
+		return 1 << uint(start), nil
+	}
+
+	return all(b), nil
+
+}
+
+// parseDays parses a BYDAY list of two-letter weekday codes.
+func parseDays(list string) (uint64, error) {
+
+	var set uint64
+
+	for day := range strings.SplitSeq(list, ",") {
+		weekday, ok := rruleDays[strings.ToUpper(day)]
+		if !ok {
+			return 0, fmt.Errorf("unsupported day %q, expected MO to SU without ordinals", day)
+		}
+		set |= 1 << uint(weekday)
+	}
+
+	return set, nil
+
+}
+
+// parseUntil parses the UNTIL part. Values without the UTC designator are local to loc.
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+
+	for _, layout := range untilLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if layout == "20060102" {
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond) // the whole day is included
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+
+}
+
+// all returns a bit set with every value of the bounds.
+func all(b bounds) uint64 {
+	var set uint64
+	for v := b.min; v <= b.max; v++ {
+		set |= 1 << uint(v)
+	}
+	return set
+}
+
+// inInterval reports whether t falls into a period selected by the rule's INTERVAL,
+// counting periods of the rule's frequency from dtstart.
+func (r *Rule) inInterval(t time.Time) bool {
+
+	if r.interval <= 1 {
+		return true
+	}
+
+	var periods int
+
+	switch r.freq {
+	case minutely:
+		periods = int(t.Sub(r.dtstart) / time.Minute)
+	case hourly:
+		start := time.Date(r.dtstart.Year(), r.dtstart.Month(), r.dtstart.Day(), r.dtstart.Hour(), 0, 0, 0, r.loc)
+		periods = int(t.Sub(start) / time.Hour)
+	case daily:
+		periods = civilDay(t) - civilDay(r.dtstart)
+	case weekly:
+		periods = (civilDay(r.startOfWeek(t)) - civilDay(r.startOfWeek(r.dtstart))) / 7
+	case monthly:
+		periods = (t.Year()-r.dtstart.Year())*12 + int(t.Month()) - int(r.dtstart.Month())
+	case yearly:
+		periods = t.Year() - r.dtstart.Year()
+	}
+
+	return periods%r.interval == 0
+
+}
+
+// startOfWeek returns the first day of the week containing t, according to WKST.
+func (r *Rule) startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(r.weekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// civilDay returns the number of days between the Unix epoch and the calendar date of t.
+func civilDay(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
@@ -13,6 +13,7 @@ import (
 	models "Chronos/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,95 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AdvanceSchedule mocks base method.
+func (m *MockStorage) AdvanceSchedule(ctx context.Context, scheduleID, currentID string, next *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceSchedule", ctx, scheduleID, currentID, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvanceSchedule indicates an expected call of AdvanceSchedule.
+func (mr *MockStorageMockRecorder) AdvanceSchedule(ctx, scheduleID, currentID, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceSchedule", reflect.TypeOf((*MockStorage)(nil).AdvanceSchedule), ctx, scheduleID, currentID, next)
+}
+
+// CancelByTag mocks base method.
+func (m *MockStorage) CancelByTag(ctx context.Context, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByTag", ctx, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelByTag indicates an expected call of CancelByTag.
+func (mr *MockStorageMockRecorder) CancelByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByTag", reflect.TypeOf((*MockStorage)(nil).CancelByTag), ctx, tag)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockStorage) CancelSchedule(ctx context.Context, scheduleID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockStorageMockRecorder) CancelSchedule(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockStorage)(nil).CancelSchedule), ctx, scheduleID)
+}
+
+// ClaimDue mocks base method.
+func (m *MockStorage) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, lease)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockStorageMockRecorder) ClaimDue(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockStorage)(nil).ClaimDue), ctx, limit, lease)
+}
+
+// ClaimNotification mocks base method.
+func (m *MockStorage) ClaimNotification(ctx context.Context, notificationID string, revision int, lease time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotification", ctx, notificationID, revision, lease)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotification indicates an expected call of ClaimNotification.
+func (mr *MockStorageMockRecorder) ClaimNotification(ctx, notificationID, revision, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotification", reflect.TypeOf((*MockStorage)(nil).ClaimNotification), ctx, notificationID, revision, lease)
+}
+
+// ClaimOutbox mocks base method.
+func (m *MockStorage) ClaimOutbox(ctx context.Context, lease time.Duration) ([]models.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutbox", ctx, lease)
+	ret0, _ := ret[0].([]models.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutbox indicates an expected call of ClaimOutbox.
+func (mr *MockStorageMockRecorder) ClaimOutbox(ctx, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutbox", reflect.TypeOf((*MockStorage)(nil).ClaimOutbox), ctx, lease)
+}
+
 // Cleanup mocks base method.
 func (m *MockStorage) Cleanup(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -65,6 +155,20 @@ func (mr *MockStorageMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStorage)(nil).Close))
 }
 
+// CreateDeadLetter mocks base method.
+func (m *MockStorage) CreateDeadLetter(ctx context.Context, deadLetter models.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", ctx, deadLetter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockStorageMockRecorder) CreateDeadLetter(ctx, deadLetter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockStorage)(nil).CreateDeadLetter), ctx, deadLetter)
+}
+
 // CreateNotification mocks base method.
 func (m *MockStorage) CreateNotification(ctx context.Context, notification models.Notification) error {
 	m.ctrl.T.Helper()
@@ -79,6 +183,63 @@ func (mr *MockStorageMockRecorder) CreateNotification(ctx, notification any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStorage)(nil).CreateNotification), ctx, notification)
 }
 
+// CreateNotificationOnce mocks base method.
+func (m *MockStorage) CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationOnce", ctx, notification, key)
+	ret0, _ := ret[0].(models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotificationOnce indicates an expected call of CreateNotificationOnce.
+func (mr *MockStorageMockRecorder) CreateNotificationOnce(ctx, notification, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationOnce", reflect.TypeOf((*MockStorage)(nil).CreateNotificationOnce), ctx, notification, key)
+}
+
+// CreateNotifications mocks base method.
+func (m *MockStorage) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", ctx, notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockStorageMockRecorder) CreateNotifications(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockStorage)(nil).CreateNotifications), ctx, notifications)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockStorage) CreateSchedule(ctx context.Context, schedule models.Schedule, first models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule, first)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockStorageMockRecorder) CreateSchedule(ctx, schedule, first any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockStorage)(nil).CreateSchedule), ctx, schedule, first)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockStorage) CreateTemplate(ctx context.Context, tmpl models.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, tmpl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockStorageMockRecorder) CreateTemplate(ctx, tmpl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockStorage)(nil).CreateTemplate), ctx, tmpl)
+}
+
 // DeleteNotification mocks base method.
 func (m *MockStorage) DeleteNotification(ctx context.Context, notificationID string) error {
 	m.ctrl.T.Helper()
@@ -93,6 +254,20 @@ func (mr *MockStorageMockRecorder) DeleteNotification(ctx, notificationID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockStorage)(nil).DeleteNotification), ctx, notificationID)
 }
 
+// DeleteTemplate mocks base method.
+func (m *MockStorage) DeleteTemplate(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockStorageMockRecorder) DeleteTemplate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockStorage)(nil).DeleteTemplate), ctx, name)
+}
+
 // GetAllStatuses mocks base method.
 func (m *MockStorage) GetAllStatuses(ctx context.Context) ([]models.Notification, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +283,81 @@ func (mr *MockStorageMockRecorder) GetAllStatuses(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStatuses", reflect.TypeOf((*MockStorage)(nil).GetAllStatuses), ctx)
 }
 
+// GetDeadLetter mocks base method.
+func (m *MockStorage) GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", ctx, deadLetterID)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockStorageMockRecorder) GetDeadLetter(ctx, deadLetterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockStorage)(nil).GetDeadLetter), ctx, deadLetterID)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockStorage) GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockStorageMockRecorder) GetIdempotencyKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockStorage)(nil).GetIdempotencyKey), ctx, key)
+}
+
+// GetNotification mocks base method.
+func (m *MockStorage) GetNotification(ctx context.Context, notificationID string) (models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotification", ctx, notificationID)
+	ret0, _ := ret[0].(models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotification indicates an expected call of GetNotification.
+func (mr *MockStorageMockRecorder) GetNotification(ctx, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotification", reflect.TypeOf((*MockStorage)(nil).GetNotification), ctx, notificationID)
+}
+
+// GetRecipients mocks base method.
+func (m *MockStorage) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipients", ctx, notificationID)
+	ret0, _ := ret[0].([]models.Recipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipients indicates an expected call of GetRecipients.
+func (mr *MockStorageMockRecorder) GetRecipients(ctx, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipients", reflect.TypeOf((*MockStorage)(nil).GetRecipients), ctx, notificationID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockStorage) GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockStorageMockRecorder) GetSchedule(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockStorage)(nil).GetSchedule), ctx, scheduleID)
+}
+
 // GetStatus mocks base method.
 func (m *MockStorage) GetStatus(ctx context.Context, notificationID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +373,66 @@ func (mr *MockStorageMockRecorder) GetStatus(ctx, notificationID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockStorage)(nil).GetStatus), ctx, notificationID)
 }
 
+// GetTemplate mocks base method.
+func (m *MockStorage) GetTemplate(ctx context.Context, name string) (models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, name)
+	ret0, _ := ret[0].(models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockStorageMockRecorder) GetTemplate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockStorage)(nil).GetTemplate), ctx, name)
+}
+
+// ListByTag mocks base method.
+func (m *MockStorage) ListByTag(ctx context.Context, tag string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTag", ctx, tag)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTag indicates an expected call of ListByTag.
+func (mr *MockStorageMockRecorder) ListByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTag", reflect.TypeOf((*MockStorage)(nil).ListByTag), ctx, tag)
+}
+
+// ListDeadLetters mocks base method.
+func (m *MockStorage) ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", ctx)
+	ret0, _ := ret[0].([]models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockStorageMockRecorder) ListDeadLetters(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockStorage)(nil).ListDeadLetters), ctx)
+}
+
+// ListTemplates mocks base method.
+func (m *MockStorage) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockStorageMockRecorder) ListTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockStorage)(nil).ListTemplates), ctx)
+}
+
 // MarkLates mocks base method.
 func (m *MockStorage) MarkLates(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +448,20 @@ func (mr *MockStorageMockRecorder) MarkLates(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLates", reflect.TypeOf((*MockStorage)(nil).MarkLates), ctx)
 }
 
+// MarkPublished mocks base method.
+func (m *MockStorage) MarkPublished(ctx context.Context, entryIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, entryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockStorageMockRecorder) MarkPublished(ctx, entryIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockStorage)(nil).MarkPublished), ctx, entryIDs)
+}
+
 // Recover mocks base method.
 func (m *MockStorage) Recover(ctx context.Context) ([]models.Notification, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +477,62 @@ func (mr *MockStorageMockRecorder) Recover(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockStorage)(nil).Recover), ctx)
 }
 
+// ReplayDeadLetter mocks base method.
+func (m *MockStorage) ReplayDeadLetter(ctx context.Context, deadLetterID string, notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetter", ctx, deadLetterID, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDeadLetter indicates an expected call of ReplayDeadLetter.
+func (mr *MockStorageMockRecorder) ReplayDeadLetter(ctx, deadLetterID, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetter", reflect.TypeOf((*MockStorage)(nil).ReplayDeadLetter), ctx, deadLetterID, notification)
+}
+
+// RescheduleNotification mocks base method.
+func (m *MockStorage) RescheduleNotification(ctx context.Context, notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleNotification indicates an expected call of RescheduleNotification.
+func (mr *MockStorageMockRecorder) RescheduleNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleNotification", reflect.TypeOf((*MockStorage)(nil).RescheduleNotification), ctx, notification)
+}
+
+// SetAttempts mocks base method.
+func (m *MockStorage) SetAttempts(ctx context.Context, notificationID string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttempts", ctx, notificationID, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttempts indicates an expected call of SetAttempts.
+func (mr *MockStorageMockRecorder) SetAttempts(ctx, notificationID, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttempts", reflect.TypeOf((*MockStorage)(nil).SetAttempts), ctx, notificationID, attempts)
+}
+
+// SetAvailableAt mocks base method.
+func (m *MockStorage) SetAvailableAt(ctx context.Context, notificationID string, availableAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvailableAt", ctx, notificationID, availableAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvailableAt indicates an expected call of SetAvailableAt.
+func (mr *MockStorageMockRecorder) SetAvailableAt(ctx, notificationID, availableAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailableAt", reflect.TypeOf((*MockStorage)(nil).SetAvailableAt), ctx, notificationID, availableAt)
+}
+
 // SetStatus mocks base method.
 func (m *MockStorage) SetStatus(ctx context.Context, notificationID, status string) error {
 	m.ctrl.T.Helper()
@@ -166,3 +546,46 @@ func (mr *MockStorageMockRecorder) SetStatus(ctx, notificationID, status any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockStorage)(nil).SetStatus), ctx, notificationID, status)
 }
+
+// StaleSchedules mocks base method.
+func (m *MockStorage) StaleSchedules(ctx context.Context) ([]models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaleSchedules", ctx)
+	ret0, _ := ret[0].([]models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StaleSchedules indicates an expected call of StaleSchedules.
+func (mr *MockStorageMockRecorder) StaleSchedules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaleSchedules", reflect.TypeOf((*MockStorage)(nil).StaleSchedules), ctx)
+}
+
+// UpdateRecipients mocks base method.
+func (m *MockStorage) UpdateRecipients(ctx context.Context, notificationID string, recipients []models.Recipient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipients", ctx, notificationID, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipients indicates an expected call of UpdateRecipients.
+func (mr *MockStorageMockRecorder) UpdateRecipients(ctx, notificationID, recipients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipients", reflect.TypeOf((*MockStorage)(nil).UpdateRecipients), ctx, notificationID, recipients)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockStorage) UpdateTemplate(ctx context.Context, tmpl models.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, tmpl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockStorageMockRecorder) UpdateTemplate(ctx, tmpl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockStorage)(nil).UpdateTemplate), ctx, tmpl)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// AdvanceSchedule moves an active schedule from its current occurrence to the next one.
+// The next occurrence is inserted in the same transaction; if next is nil, the schedule is finished instead.
+// The update only applies while currentID is still the schedule's current occurrence, so concurrent
+// or repeated attempts to advance past the same occurrence create at most one next occurrence;
+// the losers get ErrScheduleNotActive, as do attempts on canceled or finished schedules.
+// That outcome is reported after the transaction, so it is not retried like a failed query.
+func (s *Storage) AdvanceSchedule(ctx context.Context, scheduleID string, currentID string, next *models.Notification) error {
+
+	advanceQuery := `
+
+			UPDATE Schedules
+			SET current_uuid = $1, next_at = $2, occurrences = occurrences + 1, updated_at = NOW()
+			WHERE uuid = $3 AND current_uuid = $4 AND status = $5;`
+
+	finishQuery := `
+
+			UPDATE Schedules
+			SET status = $1, updated_at = NOW()
+			WHERE uuid = $2 AND current_uuid = $3 AND status = $4;`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var inactive bool
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		var result sql.Result
+		var err error
+
+		if next == nil {
+			result, err = tx.ExecContext(ctx, finishQuery, models.ScheduleFinished, scheduleID, currentID, models.ScheduleActive)
+		} else {
+			result, err = tx.ExecContext(ctx, advanceQuery, next.ID, next.SendAt, scheduleID, currentID, models.ScheduleActive)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get number of affected rows: %w", err)
+		}
+
+		if inactive = rowsAffected == 0; inactive || next == nil {
+			return nil
+		}
+
+		return insertNotification(ctx, tx, *next)
+
+	})
+
+	if err == nil && inactive {
+		return errs.ErrScheduleNotActive
+	}
+
+	return err
+
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CancelByTag cancels every notification carrying the given tag in a single query and returns
+// the IDs of the canceled ones. As in SetStatus, only notifications that are currently
+// "pending" or "running late" are canceled; the others are left untouched.
+func (s *Storage) CancelByTag(ctx context.Context, tag string) ([]string, error) {
+
+	query := `
+
+	UPDATE Notifications
+	SET status = $1, updated_at = NOW()
+	WHERE status IN ($2, $3)
+	AND uuid IN (SELECT notification_uuid FROM NotificationTags WHERE tag = $4)
+	RETURNING uuid;`
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query,
+		models.StatusCanceled, models.StatusPending, models.StatusLate, tag)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var canceled []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		canceled = append(canceled, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return canceled, nil
+
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CancelSchedule cancels an active schedule and its current occurrence if it has not been sent yet,
+// in one transaction. It returns the ID of the canceled occurrence, or an empty string if the
+// occurrence had already been processed. Canceling a schedule that is not active returns
+// ErrScheduleNotActive, and ErrScheduleNotFound is returned for unknown schedules.
+func (s *Storage) CancelSchedule(ctx context.Context, scheduleID string) (string, error) {
+
+	scheduleQuery := `
+
+			UPDATE Schedules
+			SET status = $1, updated_at = NOW()
+			WHERE uuid = $2 AND status = $3
+			RETURNING current_uuid;`
+
+	occurrenceQuery := `
+
+			UPDATE Notifications
+			SET status = $1, updated_at = NOW()
+			WHERE uuid = $2 AND status IN ($3, $4);`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var canceledID string
+	var inactive bool
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		canceledID, inactive = "", false
+
+		var currentID string
+		err := tx.QueryRowContext(ctx, scheduleQuery, models.StatusCanceled, scheduleID, models.ScheduleActive).Scan(&currentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			inactive = true
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, occurrenceQuery, models.StatusCanceled, currentID, models.StatusPending, models.StatusLate)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get number of affected rows: %w", err)
+		}
+
+		if rowsAffected > 0 {
+			canceledID = currentID
+		}
+
+		return nil
+
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if inactive {
+		if _, err := s.GetSchedule(ctx, scheduleID); err != nil {
+			return "", err
+		}
+		return "", errs.ErrScheduleNotActive
+	}
+
+	return canceledID, nil
+
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+// ClaimDue atomically moves up to limit due notifications to "sending" and returns them, oldest send time
+// first, with Status holding the status each had before it was claimed. A pending or late notification is
+// due once its send time, or the time of its next delivery attempt, has passed; a notification that has
+// been sending for longer than lease is due again, so that the work of a consumer that died mid-send is
+// taken over. Rows locked by a concurrent claim are skipped, so several pollers never claim the same row.
+func (s *Storage) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+
+	dueQuery := `
+
+	SELECT uuid, status
+	FROM Notifications
+	WHERE (status IN ($1, $2) AND COALESCE(available_at, send_at) <= NOW())
+	OR (status = $3 AND updated_at < NOW() - $4 * INTERVAL '1 millisecond')
+	ORDER BY send_at ASC
+	LIMIT $5
+	FOR UPDATE SKIP LOCKED;`
+
+	claimQuery := `
+
+	UPDATE Notifications
+	SET status = $1, updated_at = NOW()
+	WHERE uuid = ANY($2);`
+
+	query := selectNotifications + `
+		WHERE n.uuid = ANY($4)
+		ORDER BY n.send_at ASC;`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var claimed []models.Notification
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		claimed = nil
+
+		rows, err := tx.QueryContext(ctx, dueQuery,
+			models.StatusPending, models.StatusLate, models.StatusSending, lease.Milliseconds(), limit)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		previous := make(map[string]string)
+		var ids []string
+
+		for rows.Next() {
+			var id, status string
+			if err := rows.Scan(&id, &status); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan row: %w", err)
+			}
+			previous[id] = status
+			ids = append(ids, id)
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return fmt.Errorf("failed to iterate rows: %w", err)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, claimQuery, models.StatusSending, dbpg.Array(&ids)); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		rows, err = tx.QueryContext(ctx, query, append(recipientKinds(), dbpg.Array(&ids))...)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		notifications, err := scanNotifications(rows)
+		if err != nil {
+			return err
+		}
+
+		for i := range notifications {
+			notifications[i].Status = previous[notifications[i].ID]
+		}
+
+		claimed = notifications
+
+		return nil
+
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// ClaimNotification atomically moves a pending or late notification of the given revision
+// to "sending" and returns the status it had before, so that only one consumer sends it.
+// A notification that is already being sent can be taken over once its claim is older than
+// lease, which lets a redelivered message finish the work of a consumer that crashed mid-send.
+// If the notification cannot be claimed, ErrNotificationClaimed is returned.
+func (s *Storage) ClaimNotification(ctx context.Context, notificationID string, revision int, lease time.Duration) (string, error) {
+
+	query := `
+
+	UPDATE Notifications n
+	SET status = $1, updated_at = NOW()
+	FROM (SELECT uuid, status FROM Notifications WHERE uuid = $2 FOR UPDATE) previous
+	WHERE n.uuid = previous.uuid AND n.revision = $3
+	AND (n.status IN ($4, $5) OR (n.status = $1 AND n.updated_at < NOW() - $6 * INTERVAL '1 millisecond'))
+	RETURNING previous.status;`
+
+	row, err := s.db.QueryRowWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query,
+		models.StatusSending, notificationID, revision, models.StatusPending, models.StatusLate, lease.Milliseconds())
+
+	if err != nil {
+		return "", fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	var previous string
+	if err := row.Scan(&previous); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrNotificationClaimed
+		}
+		return "", fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return previous, nil
+
+}
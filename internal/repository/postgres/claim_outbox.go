@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+// ClaimOutbox atomically claims the outbox entries that have not been published to the broker yet and returns
+// them in the order they were created, limited by the recover limit, each with its notification. A claimed entry
+// is not returned again until its claim is older than lease, so relays of several instances never publish the
+// same entry twice, while the entries of a relay that died before marking them are taken over. Rows locked by
+// a concurrent claim are skipped. Every persisted field is returned, so the published messages are identical
+// to the ones a direct produce would have sent.
+func (s *Storage) ClaimOutbox(ctx context.Context, lease time.Duration) ([]models.OutboxEntry, error) {
+
+	entriesQuery := `
+
+	UPDATE Outbox
+	SET claimed_at = NOW()
+	WHERE id IN (
+		SELECT id
+		FROM Outbox
+		WHERE published_at IS NULL AND (claimed_at IS NULL OR claimed_at < NOW() - $1 * INTERVAL '1 millisecond')
+		ORDER BY id ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, notification_uuid;`
+
+	query := selectNotifications + `
+		WHERE n.uuid = ANY($4);`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	rows, err := s.db.QueryWithRetry(ctx, strategy, entriesQuery, lease.Milliseconds(), s.config.RecoverLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	var entries []models.OutboxEntry
+	var ids []string
+
+	for rows.Next() {
+		var entry models.OutboxEntry
+		if err := rows.Scan(&entry.ID, &entry.Notification.ID); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entries = append(entries, entry)
+		ids = append(ids, entry.Notification.ID)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	slices.SortFunc(entries, func(a, b models.OutboxEntry) int { return cmp.Compare(a.ID, b.ID) })
+
+	rows, err = s.db.QueryWithRetry(ctx, strategy, query, append(recipientKinds(), dbpg.Array(&ids))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.Notification, len(notifications))
+	for _, notification := range notifications {
+		byID[notification.ID] = notification
+	}
+
+	listed := entries[:0]
+	for _, entry := range entries {
+		notification, ok := byID[entry.Notification.ID]
+		if !ok { // deleted meanwhile, its entry is deleted with it
+			continue
+		}
+		entry.Notification = notification
+		listed = append(listed, entry)
+	}
+
+	return listed, nil
+
+}
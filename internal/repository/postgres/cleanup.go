@@ -7,8 +7,11 @@ import (
 	"github.com/wb-go/wbf/retry"
 )
 
-// Cleanup removes outdated notifications from the database
-// based on retention rules for each notification status.
+// Cleanup removes outdated notifications and recurring schedules from the database
+// based on retention rules for each status. Partially sent and expired notifications are kept
+// as long as failed ones. Finished schedules are kept as long as sent notifications, canceled
+// schedules as long as canceled notifications, dead letters as long as failed notifications.
+// Expired idempotency keys and published outbox entries are removed as well.
 func (s *Storage) Cleanup(ctx context.Context) {
 
 	query := `
@@ -16,7 +19,7 @@ func (s *Storage) Cleanup(ctx context.Context) {
         DELETE FROM Notifications 
         WHERE (status = $1 AND updated_at < NOW() - $2 * INTERVAL '1 second')
         OR (status = $3 AND updated_at < NOW() - $4 * INTERVAL '1 second')
-        OR (status IN ($5, $6) AND updated_at < NOW() - $7 * INTERVAL '1 second');`
+        OR (status IN ($5, $6, $7, $8) AND updated_at < NOW() - $9 * INTERVAL '1 second');`
 
 	_, err := s.db.ExecWithRetry(ctx, retry.Strategy{
 		Attempts: s.config.QueryRetryStrategy.Attempts,
@@ -25,7 +28,8 @@ func (s *Storage) Cleanup(ctx context.Context) {
 
 		models.StatusCanceled, int(s.config.RetentionStrategy.Canceled.Seconds()),
 		models.StatusSent, int(s.config.RetentionStrategy.Completed.Seconds()),
-		models.StatusFailed, models.StatusFailedToSendInTime, int(s.config.RetentionStrategy.Failed.Seconds()),
+		models.StatusFailed, models.StatusPartiallySent, models.StatusFailedToSendInTime, models.StatusExpired,
+		int(s.config.RetentionStrategy.Failed.Seconds()),
 	)
 
 	if err != nil {
@@ -34,4 +38,67 @@ func (s *Storage) Cleanup(ctx context.Context) {
 		s.logger.Debug("postgres — old notifications cleaned", "layer", "repository.postgres")
 	}
 
+	schedulesQuery := `
+
+        DELETE FROM Schedules
+        WHERE (status = $1 AND updated_at < NOW() - $2 * INTERVAL '1 second')
+        OR (status = $3 AND updated_at < NOW() - $4 * INTERVAL '1 second');`
+
+	_, err = s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, schedulesQuery,
+
+		models.StatusCanceled, int(s.config.RetentionStrategy.Canceled.Seconds()),
+		models.ScheduleFinished, int(s.config.RetentionStrategy.Completed.Seconds()),
+	)
+
+	if err != nil {
+		s.logger.LogError("postgres — failed to delete old schedules", err, "layer", "repository.postgres")
+	}
+
+	deadLettersQuery := `
+
+        DELETE FROM DeadLetters
+        WHERE created_at < NOW() - $1 * INTERVAL '1 second';`
+
+	_, err = s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, deadLettersQuery,
+		int(s.config.RetentionStrategy.Failed.Seconds()),
+	)
+
+	if err != nil {
+		s.logger.LogError("postgres — failed to delete old dead letters", err, "layer", "repository.postgres")
+	}
+
+	keysQuery := `
+
+        DELETE FROM IdempotencyKeys
+        WHERE expires_at <= NOW();`
+
+	_, err = s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, keysQuery)
+
+	if err != nil {
+		s.logger.LogError("postgres — failed to delete expired idempotency keys", err, "layer", "repository.postgres")
+	}
+
+	outboxQuery := `
+
+        DELETE FROM Outbox
+        WHERE published_at IS NOT NULL;`
+
+	_, err = s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, outboxQuery)
+
+	if err != nil {
+		s.logger.LogError("postgres — failed to delete published outbox entries", err, "layer", "repository.postgres")
+	}
+
 }
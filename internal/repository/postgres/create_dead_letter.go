@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CreateDeadLetter saves a message that could not be delivered together with the reason.
+func (s *Storage) CreateDeadLetter(ctx context.Context, deadLetter models.DeadLetter) error {
+
+	query := `
+
+		INSERT INTO DeadLetters (uuid, notification_uuid, reason, body, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);`
+
+	_, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query,
+		deadLetter.ID, deadLetter.NotificationID, deadLetter.Reason, deadLetter.Body,
+		deadLetter.Attempts, deadLetter.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+
+}
@@ -4,13 +4,12 @@ import (
 	"Chronos/internal/models"
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/wb-go/wbf/retry"
 )
 
 // CreateNotification saves a new notification and its recipients in one transaction.
-// For email notifications, recipients are stored in a separate table.
+// Recipients of any channel are stored in a separate table together with their kind (to, cc, bcc).
 func (s *Storage) CreateNotification(ctx context.Context, notification models.Notification) error {
 
 	strategy := retry.Strategy{
@@ -19,39 +18,8 @@ func (s *Storage) CreateNotification(ctx context.Context, notification models.No
 		Backoff:  s.config.QueryRetryStrategy.Backoff,
 	}
 
-	notificationsQuery := `
-
-			INSERT INTO Notifications (uuid, channel, message, status, send_at, send_at_local, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7);`
-
-	recipientsQuery := `
-
-			INSERT INTO Recipients (notification_uuid, recipient)
-			VALUES ($1, $2);`
-
 	return s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
-
-		_, err := tx.ExecContext(ctx, notificationsQuery,
-			notification.ID, notification.Channel,
-			notification.Message, notification.Status,
-			notification.SendAt, notification.SendAtLocal, notification.UpdatedAt)
-
-		if err != nil {
-			return fmt.Errorf("failed to execute query: %w", err)
-		}
-
-		if notification.Channel == models.Email {
-
-			for _, recipient := range notification.SendTo {
-				if _, err := tx.ExecContext(ctx, recipientsQuery, notification.ID, recipient); err != nil {
-					return fmt.Errorf("failed to execute query: %w", err)
-				}
-			}
-
-		}
-
-		return nil
-
+		return insertNotification(ctx, tx, notification)
 	})
 
 }
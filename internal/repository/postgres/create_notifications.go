@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CreateNotifications saves a batch of notifications and their recipients in one transaction,
+// so either the whole batch is stored or none of it is.
+func (s *Storage) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	return s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+		for _, notification := range notifications {
+			if err := insertNotification(ctx, tx, notification); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CreateSchedule saves a new recurring schedule together with its first occurrence in one transaction,
+// so a schedule never exists without a pending occurrence.
+func (s *Storage) CreateSchedule(ctx context.Context, schedule models.Schedule, first models.Notification) error {
+
+	notification, err := json.Marshal(schedule.Notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule notification: %w", err)
+	}
+
+	query := `
+
+			INSERT INTO Schedules (uuid, cron, rrule, time_zone, start_at, end_at, max_occurrences, max_delay,
+			occurrences, status, current_uuid, next_at, notification, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	return s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		_, err := tx.ExecContext(ctx, query,
+			schedule.ID, schedule.Cron, schedule.RRule, schedule.TimeZone, schedule.StartAt, schedule.EndAt,
+			schedule.MaxOccurrences, schedule.MaxDelay, schedule.Occurrences, schedule.Status, schedule.CurrentID, schedule.NextAt,
+			notification, schedule.CreatedAt, schedule.UpdatedAt)
+
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		return insertNotification(ctx, tx, first)
+
+	})
+
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// CreateTemplate saves a new template. Name conflicts are resolved by the database:
+// if a template with the same name already exists, nothing is inserted and ErrTemplateExists is returned.
+func (s *Storage) CreateTemplate(ctx context.Context, tmpl models.Template) error {
+
+	variants, err := json.Marshal(tmpl.Variants)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template variants: %w", err)
+	}
+
+	query := `
+
+	INSERT INTO Templates (name, variants, created_at, updated_at)
+	VALUES ($1, $2, $3, $3)
+	ON CONFLICT (name) DO NOTHING;`
+
+	result, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, tmpl.Name, variants, tmpl.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errs.ErrTemplateExists
+	}
+
+	return nil
+
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"database/sql"
+	"fmt"
+)
+
+// selectDeadLetters selects every persisted field of a dead letter.
+// Callers append their own WHERE, ORDER BY and LIMIT clauses and scan rows with scanDeadLetter.
+const selectDeadLetters = `
+
+		SELECT d.uuid, d.notification_uuid, d.reason, d.body, d.attempts, d.created_at, d.replayed_at
+		FROM DeadLetters d`
+
+// scanDeadLetter reads a single row produced by a query built on selectDeadLetters.
+func scanDeadLetter(row interface{ Scan(dest ...any) error }) (models.DeadLetter, error) {
+
+	var deadLetter models.DeadLetter
+
+	if err := row.Scan(&deadLetter.ID, &deadLetter.NotificationID, &deadLetter.Reason, &deadLetter.Body,
+		&deadLetter.Attempts, &deadLetter.CreatedAt, &deadLetter.ReplayedAt); err != nil {
+		return models.DeadLetter{}, err
+	}
+
+	return deadLetter, nil
+
+}
+
+// scanDeadLetters reads all rows produced by a query built on selectDeadLetters.
+func scanDeadLetters(rows *sql.Rows) ([]models.DeadLetter, error) {
+
+	var deadLetters []models.DeadLetter
+
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return deadLetters, nil
+
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// DeleteTemplate deletes a template by its name. Templates referenced by pending, late or sending
+// notifications are kept, since those notifications are rendered only when they are sent, as are
+// templates referenced by the blueprint of an active schedule, whose future occurrences render them.
+// If no rows are affected, the template is looked up once more to tell ErrTemplateInUse
+// apart from ErrTemplateNotFound.
+func (s *Storage) DeleteTemplate(ctx context.Context, name string) error {
+
+	query := `
+
+	DELETE FROM Templates t
+	WHERE t.name = $1
+	AND NOT EXISTS (
+		SELECT 1 FROM Notifications n
+		WHERE n.template_name = t.name AND n.status IN ($2, $3, $4)
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM Schedules s
+		WHERE s.notification->>'template' = t.name AND s.status = $5
+	);`
+
+	result, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, name,
+		models.StatusPending, models.StatusLate, models.StatusSending, models.ScheduleActive)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	if _, err := s.GetTemplate(ctx, name); err != nil {
+		if errors.Is(err, errs.ErrTemplateNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to check template existence: %w", err)
+	}
+
+	return errs.ErrTemplateInUse
+
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetDeadLetter returns a dead letter by its ID.
+func (s *Storage) GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error) {
+
+	query := selectDeadLetters + `
+		WHERE d.uuid = $1;`
+
+	row, err := s.db.QueryRowWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, deadLetterID)
+
+	if err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	deadLetter, err := scanDeadLetter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DeadLetter{}, errs.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return deadLetter, nil
+
+}
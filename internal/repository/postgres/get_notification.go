@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetNotification returns every persisted field of a notification by its ID.
+func (s *Storage) GetNotification(ctx context.Context, notificationID string) (models.Notification, error) {
+
+	query := selectNotifications + `
+		WHERE n.uuid = $4;`
+
+	args := append(recipientKinds(), notificationID)
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, args...)
+
+	if err != nil {
+		return models.Notification{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return models.Notification{}, err
+	}
+
+	if len(notifications) == 0 {
+		return models.Notification{}, errs.ErrNotificationNotFound
+	}
+
+	return notifications[0], nil
+
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetRecipients returns the delivery state of every recipient of a notification,
+// in the order they were listed when the notification was created.
+func (s *Storage) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+
+	query := `
+
+	SELECT recipient, kind, status, attempts, last_error, delivered_at
+	FROM Recipients
+	WHERE notification_uuid = $1
+	ORDER BY id;`
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, notificationID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var recipients []models.Recipient
+
+	for rows.Next() {
+		var r models.Recipient
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(&r.Address, &r.Kind, &r.Status, &r.Attempts, &r.LastError, &deliveredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if deliveredAt.Valid {
+			r.DeliveredAt = &deliveredAt.Time
+		}
+		recipients = append(recipients, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return recipients, nil
+
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetSchedule returns a recurring schedule by its ID.
+func (s *Storage) GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error) {
+
+	query := selectSchedules + `
+		WHERE s.uuid = $1;`
+
+	row, err := s.db.QueryRowWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, scheduleID)
+
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	schedule, err := scanSchedule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Schedule{}, errs.ErrScheduleNotFound
+	}
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return schedule, nil
+
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetTemplate returns a template by its name.
+func (s *Storage) GetTemplate(ctx context.Context, name string) (models.Template, error) {
+
+	query := selectTemplates + `
+		WHERE name = $1;`
+
+	row, err := s.db.QueryRowWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, name)
+
+	if err != nil {
+		return models.Template{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	tmpl, err := scanTemplate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Template{}, errs.ErrTemplateNotFound
+	}
+	if err != nil {
+		return models.Template{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return tmpl, nil
+
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// GetIdempotencyKey returns the notification an idempotency key maps to.
+// Expired keys are treated as unknown and ErrUnknownIdempotencyKey is returned.
+func (s *Storage) GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyKey, error) {
+
+	query := `
+
+	SELECT key, request_hash, notification_uuid
+	FROM IdempotencyKeys
+	WHERE key = $1 AND expires_at > NOW();`
+
+	row, err := s.db.QueryRowWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, key)
+
+	if err != nil {
+		return models.IdempotencyKey{}, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	var stored models.IdempotencyKey
+	if err := row.Scan(&stored.Key, &stored.RequestHash, &stored.NotificationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdempotencyKey{}, errs.ErrUnknownIdempotencyKey
+		}
+		return models.IdempotencyKey{}, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return stored, nil
+
+}
+
+// CreateNotificationOnce inserts a notification and binds the idempotency key to it in one transaction.
+// If the key is already bound to another notification and has not expired, nothing is inserted and
+// the existing binding is returned instead; callers compare its NotificationID with the notification's ID
+// to tell the two cases apart. Concurrent requests with the same key are serialized by the key's
+// primary key, so at most one of them creates a notification.
+// The key expires after the configured idempotency retention and is deleted together with its notification.
+func (s *Storage) CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+
+	bindQuery := `
+
+			INSERT INTO IdempotencyKeys (key, request_hash, notification_uuid, expires_at)
+			VALUES ($1, $2, $3, NOW() + $4 * INTERVAL '1 second')
+			ON CONFLICT (key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, notification_uuid = EXCLUDED.notification_uuid, expires_at = EXCLUDED.expires_at
+			WHERE IdempotencyKeys.expires_at <= NOW()
+			RETURNING key;`
+
+	existingQuery := `
+
+			SELECT key, request_hash, notification_uuid
+			FROM IdempotencyKeys
+			WHERE key = $1;`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var stored models.IdempotencyKey
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		key.NotificationID = notification.ID
+		stored = key
+
+		var bound string
+		err := tx.QueryRowContext(ctx, bindQuery, key.Key, key.RequestHash, notification.ID,
+			int(s.config.RetentionStrategy.Idempotency.Seconds())).Scan(&bound)
+
+		switch {
+		case err == nil:
+			return insertNotification(ctx, tx, notification)
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		if err := tx.QueryRowContext(ctx, existingQuery, key.Key).Scan(
+			&stored.Key, &stored.RequestHash, &stored.NotificationID); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		return nil
+
+	})
+
+	if err != nil {
+		return models.IdempotencyKey{}, err
+	}
+
+	return stored, nil
+
+}
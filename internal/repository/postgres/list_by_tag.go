@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// ListByTag returns every notification carrying the given tag, ordered by send time.
+func (s *Storage) ListByTag(ctx context.Context, tag string) ([]models.Notification, error) {
+
+	query := selectNotifications + `
+		WHERE n.uuid IN (SELECT notification_uuid FROM NotificationTags WHERE tag = $4)
+		ORDER BY n.send_at ASC;`
+
+	args := append(recipientKinds(), tag)
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanNotifications(rows)
+
+}
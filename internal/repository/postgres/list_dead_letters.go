@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// ListDeadLetters returns the dead letters that have not been replayed yet, oldest first,
+// at most models.MaxDeadLetters of them.
+func (s *Storage) ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error) {
+
+	query := selectDeadLetters + `
+		WHERE d.replayed_at IS NULL
+		ORDER BY d.created_at ASC
+		LIMIT $1;`
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, models.MaxDeadLetters)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDeadLetters(rows)
+
+}
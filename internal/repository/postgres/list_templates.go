@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// ListTemplates returns all templates ordered by name.
+func (s *Storage) ListTemplates(ctx context.Context) ([]models.Template, error) {
+
+	query := selectTemplates + `
+		ORDER BY name ASC;`
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanTemplates(rows)
+
+}
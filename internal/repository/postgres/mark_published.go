@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+// MarkPublished marks the given outbox entries as published. Entries are marked by their own ID, so an entry
+// added for the same notification after the listing, by a reschedule or a replay, is still published.
+// Published entries are kept until the next cleanup.
+func (s *Storage) MarkPublished(ctx context.Context, entryIDs []int64) error {
+
+	query := `
+
+	UPDATE Outbox
+	SET published_at = NOW()
+	WHERE id = ANY($1::INTEGER[]) AND published_at IS NULL;`
+
+	ids := make([]string, 0, len(entryIDs))
+	for _, id := range entryIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+
+	_, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, dbpg.Array(&ids))
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wb-go/wbf/dbpg"
+)
+
+// selectNotifications selects every persisted field of a notification together with its
+// recipients grouped by kind and its tags. Placeholders $1, $2 and $3 are bound to the to, cc and bcc kinds;
+// callers append their own JOIN, WHERE, ORDER BY and LIMIT clauses and scan rows with scanNotifications.
+// Recipients and tags are aggregated in lateral subqueries, so only the rows of the selected notifications
+// are read, rather than the whole Recipients and NotificationTags tables.
+const selectNotifications = `
+
+		SELECT n.uuid, n.channel, n.subject, n.message, n.html_message, n.reply_to, n.message_id,
+		n.template_name, n.params, n.schedule_uuid, n.revision, n.attempts, n.priority, n.status, n.send_at, n.send_at_local, n.time_zone, n.expires_at, r.send_to, r.cc, r.bcc, t.tags, n.updated_at
+		FROM notifications n
+		LEFT JOIN LATERAL (
+			SELECT
+			array_agg(recipient ORDER BY id) FILTER (WHERE kind = $1) AS send_to,
+			array_agg(recipient ORDER BY id) FILTER (WHERE kind = $2) AS cc,
+			array_agg(recipient ORDER BY id) FILTER (WHERE kind = $3) AS bcc
+			FROM recipients
+			WHERE notification_uuid = n.uuid
+		) r ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT array_agg(tag ORDER BY tag) AS tags
+			FROM notificationtags
+			WHERE notification_uuid = n.uuid
+		) t ON TRUE`
+
+// recipientKinds returns the arguments bound to the first three placeholders of selectNotifications.
+func recipientKinds() []any {
+	return []any{models.RecipientTo, models.RecipientCC, models.RecipientBCC}
+}
+
+// scanNotifications reads all rows produced by a query built on selectNotifications.
+func scanNotifications(rows *sql.Rows) ([]models.Notification, error) {
+
+	var notifications []models.Notification
+
+	for rows.Next() {
+		var n models.Notification
+		var params []byte
+		if err := rows.Scan(
+			&n.ID, &n.Channel, &n.Subject, &n.Message, &n.HTMLMessage, &n.ReplyTo, &n.MessageID,
+			&n.Template, &params, &n.ScheduleID, &n.Revision, &n.Attempts, &n.Priority, &n.Status, &n.SendAt, &n.SendAtLocal, &n.TimeZone, &n.ExpiresAt, dbpg.Array(&n.SendTo),
+			dbpg.Array(&n.CC), dbpg.Array(&n.BCC), dbpg.Array(&n.Tags), &n.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := unmarshalParams(params, &n.Params); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return notifications, nil
+
+}
+
+// insertNotification inserts a notification, its recipients and its tags within the given transaction,
+// together with an outbox entry from which the broker relay publishes the notification once the transaction commits.
+// It is shared by every method that creates notifications, such as CreateNotification
+// and the methods producing occurrences of recurring schedules.
+func insertNotification(ctx context.Context, tx *sql.Tx, notification models.Notification) error {
+
+	notificationsQuery := `
+
+			INSERT INTO Notifications (uuid, channel, subject, message, html_message, reply_to, message_id,
+			template_name, params, schedule_uuid, priority, status, send_at, send_at_local, time_zone, expires_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17);`
+
+	recipientsQuery := `
+
+			INSERT INTO Recipients (notification_uuid, recipient, kind)
+			VALUES ($1, $2, $3);`
+
+	tagsQuery := `
+
+			INSERT INTO NotificationTags (tag, notification_uuid)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING;`
+
+	params, err := marshalParams(notification.Params)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, notificationsQuery,
+		notification.ID, notification.Channel, notification.Subject,
+		notification.Message, notification.HTMLMessage,
+		notification.ReplyTo, notification.MessageID,
+		notification.Template, params, notification.ScheduleID, notification.Priority, notification.Status,
+		notification.SendAt, notification.SendAtLocal, notification.TimeZone, notification.ExpiresAt, notification.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	recipients := []struct {
+		kind string
+		list []string
+	}{
+		{models.RecipientTo, notification.SendTo},
+		{models.RecipientCC, notification.CC},
+		{models.RecipientBCC, notification.BCC},
+	}
+
+	for _, r := range recipients {
+		for _, recipient := range r.list {
+			if _, err := tx.ExecContext(ctx, recipientsQuery, notification.ID, recipient, r.kind); err != nil {
+				return fmt.Errorf("failed to execute query: %w", err)
+			}
+		}
+	}
+
+	for _, tag := range notification.Tags {
+		if _, err := tx.ExecContext(ctx, tagsQuery, tag, notification.ID); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+
+	return insertOutbox(ctx, tx, notification.ID)
+
+}
+
+// insertOutbox adds an outbox entry for a notification within the given transaction, so that the broker
+// relay publishes the notification as it is stored once the transaction commits. Besides new notifications,
+// it is used for notifications that are rescheduled or replayed and therefore need a new message.
+func insertOutbox(ctx context.Context, tx *sql.Tx, notificationID string) error {
+
+	query := `
+
+			INSERT INTO Outbox (notification_uuid)
+			VALUES ($1);`
+
+	if _, err := tx.ExecContext(ctx, query, notificationID); err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+
+}
+
+// marshalParams encodes template params for the params JSONB column.
+// Notifications without params are stored with an empty object.
+func marshalParams(params map[string]any) ([]byte, error) {
+
+	if len(params) == 0 {
+		return []byte("{}"), nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template params: %w", err)
+	}
+
+	return data, nil
+
+}
+
+// unmarshalParams decodes the params JSONB column, leaving params nil if it holds an empty object.
+func unmarshalParams(data []byte, params *map[string]any) error {
+
+	if err := json.Unmarshal(data, params); err != nil {
+		return fmt.Errorf("failed to unmarshal template params: %w", err)
+	}
+
+	if len(*params) == 0 {
+		*params = nil
+	}
+
+	return nil
+
+}
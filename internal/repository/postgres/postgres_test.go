@@ -9,12 +9,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	wbf "github.com/wb-go/wbf/config"
 	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/helpers"
 	"github.com/wb-go/wbf/retry"
 )
 
@@ -39,6 +41,7 @@ func TestMain(m *testing.M) {
 			Delay:    100 * time.Millisecond,
 			Backoff:  1.5,
 		},
+		RetentionStrategy: config.Retention{Idempotency: time.Hour},
 	}
 
 	logger, _ := logger.NewLogger(config.Logger{Debug: true})
@@ -124,6 +127,15 @@ func TestCleanup(t *testing.T) {
 			UpdatedAt: time.Now().Add(-2 * time.Hour),
 			SendTo:    []string{"test2@qwerty.com"},
 		},
+		{
+			ID:        fmt.Sprintf("cleanup-%d", time.Now().UnixNano()+2),
+			Channel:   models.Email,
+			Message:   "Partially sent",
+			Status:    models.StatusPartiallySent,
+			SendAt:    time.Now().Add(-2 * time.Hour),
+			UpdatedAt: time.Now().Add(-2 * time.Hour),
+			SendTo:    []string{"test3@qwerty.com", "test4@qwerty.com"},
+		},
 	}
 
 	for _, n := range notifications {
@@ -377,19 +389,270 @@ func TestSetStatus(t *testing.T) {
 
 }
 
+func TestSetAttempts(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("attempts-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "Test message",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(1 * time.Hour),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("failed to create notification: %v", err)
+	}
+
+	if err := testStorage.SetAttempts(ctx, n.ID, 3); err != nil {
+		t.Fatalf("SetAttempts failed: %v", err)
+	}
+
+	got, err := testStorage.GetNotification(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetNotification failed: %v", err)
+	}
+	if got.Attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got.Attempts)
+	}
+
+	if err := testStorage.SetAttempts(ctx, "nonexistent-id", 1); err != errs.ErrNotificationNotFound {
+		t.Fatalf("expected ErrNotificationNotFound, got %v", err)
+	}
+
+}
+
+func TestClaimNotification(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("claim-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "Test message",
+		Status:    models.StatusLate,
+		SendAt:    time.Now().Add(-1 * time.Minute),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("failed to create notification: %v", err)
+	}
+
+	previous, err := testStorage.ClaimNotification(ctx, n.ID, 0, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimNotification failed: %v", err)
+	}
+	if previous != models.StatusLate {
+		t.Fatalf("expected previous status %q, got %q", models.StatusLate, previous)
+	}
+
+	status, err := testStorage.GetStatus(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != models.StatusSending {
+		t.Fatalf("expected status %q, got %q", models.StatusSending, status)
+	}
+
+	if _, err := testStorage.ClaimNotification(ctx, n.ID, 0, time.Hour); err != errs.ErrNotificationClaimed {
+		t.Fatalf("expected ErrNotificationClaimed for a fresh claim, got %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	previous, err = testStorage.ClaimNotification(ctx, n.ID, 0, time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected an expired claim to be taken over, got %v", err)
+	}
+	if previous != models.StatusSending {
+		t.Fatalf("expected previous status %q, got %q", models.StatusSending, previous)
+	}
+
+	if _, err := testStorage.ClaimNotification(ctx, n.ID, 1, 0); err != errs.ErrNotificationClaimed {
+		t.Fatalf("expected ErrNotificationClaimed for another revision, got %v", err)
+	}
+
+	if err := testStorage.SetStatus(ctx, n.ID, models.StatusSent); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	if _, err := testStorage.ClaimNotification(ctx, n.ID, 0, 0); err != errs.ErrNotificationClaimed {
+		t.Fatalf("expected ErrNotificationClaimed for a sent notification, got %v", err)
+	}
+
+}
+
+func TestClaimDue(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("claim-due-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "Test message",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(-1 * time.Minute),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("failed to create notification: %v", err)
+	}
+
+	claimed := func() *models.Notification {
+
+		notifications, err := testStorage.ClaimDue(ctx, 1000, time.Hour)
+		if err != nil {
+			t.Fatalf("ClaimDue failed: %v", err)
+		}
+
+		for _, notification := range notifications {
+			if notification.ID == n.ID {
+				return &notification
+			}
+		}
+
+		return nil
+
+	}
+
+	notification := claimed()
+	if notification == nil {
+		t.Fatal("expected a due notification to be claimed")
+	}
+	if notification.Status != models.StatusPending {
+		t.Fatalf("expected previous status %q, got %q", models.StatusPending, notification.Status)
+	}
+
+	status, err := testStorage.GetStatus(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != models.StatusSending {
+		t.Fatalf("expected status %q, got %q", models.StatusSending, status)
+	}
+
+	if claimed() != nil {
+		t.Fatal("expected a fresh claim not to be claimed again")
+	}
+
+	if err := testStorage.SetStatus(ctx, n.ID, models.StatusPending); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	if err := testStorage.SetAvailableAt(ctx, n.ID, time.Now().Add(1*time.Hour)); err != nil {
+		t.Fatalf("SetAvailableAt failed: %v", err)
+	}
+
+	if claimed() != nil {
+		t.Fatal("expected a notification to be held back until it becomes available")
+	}
+
+	if err := testStorage.SetAvailableAt(ctx, n.ID, time.Now().Add(-1*time.Second)); err != nil {
+		t.Fatalf("SetAvailableAt failed: %v", err)
+	}
+
+	if claimed() == nil {
+		t.Fatal("expected an available notification to be claimed")
+	}
+
+	if err := testStorage.SetAvailableAt(ctx, "non-existent-id", time.Now()); err != errs.ErrNotificationNotFound {
+		t.Fatalf("expected ErrNotificationNotFound, got %v", err)
+	}
+
+}
+
+func TestUpdateRecipients(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        "recipients-test-1",
+		Channel:   models.Email,
+		Message:   "Test message",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(1 * time.Hour),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"delivered@example.com", "bounced@example.com"},
+		CC:        []string{"cc@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("CreateNotification failed: %v", err)
+	}
+
+	recipients, err := testStorage.GetRecipients(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetRecipients failed: %v", err)
+	}
+
+	if len(recipients) != 3 {
+		t.Fatalf("expected 3 recipients, got %d", len(recipients))
+	}
+
+	for _, r := range recipients {
+		if r.Status != models.StatusPending || r.Attempts != 0 || r.DeliveredAt != nil {
+			t.Fatalf("unexpected initial recipient state: %+v", r)
+		}
+	}
+
+	if recipients[2].Kind != models.RecipientCC {
+		t.Fatalf("expected cc recipient last, got %+v", recipients[2])
+	}
+
+	deliveredAt := time.Now().UTC()
+	err = testStorage.UpdateRecipients(ctx, n.ID, []models.Recipient{
+		{Address: "delivered@example.com", Status: models.StatusSent, Attempts: 1, DeliveredAt: &deliveredAt},
+		{Address: "bounced@example.com", Status: models.StatusFailed, Attempts: 1, LastError: "550 no such user"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateRecipients failed: %v", err)
+	}
+
+	recipients, err = testStorage.GetRecipients(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetRecipients failed: %v", err)
+	}
+
+	if recipients[0].Status != models.StatusSent || recipients[0].DeliveredAt == nil {
+		t.Fatalf("expected delivered recipient, got %+v", recipients[0])
+	}
+
+	if recipients[1].Status != models.StatusFailed || recipients[1].LastError != "550 no such user" || recipients[1].Attempts != 1 {
+		t.Fatalf("expected failed recipient, got %+v", recipients[1])
+	}
+
+	if recipients[2].Status != models.StatusPending {
+		t.Fatalf("expected untouched recipient, got %+v", recipients[2])
+	}
+
+}
+
 func TestGetAllStatuses(t *testing.T) {
 
 	ctx := context.Background()
 
 	notifications := []models.Notification{
 		{
-			ID:        fmt.Sprintf("status-1-%d", time.Now().UnixNano()),
-			Channel:   models.Email,
-			Message:   "First notification",
-			Status:    models.StatusPending,
-			SendAt:    time.Now().Add(1 * time.Hour),
-			UpdatedAt: time.Now(),
-			SendTo:    []string{"first@qweqwe.com"},
+			ID:          fmt.Sprintf("status-1-%d", time.Now().UnixNano()),
+			Channel:     models.Email,
+			Subject:     "First subject",
+			Message:     "First notification",
+			HTMLMessage: "<p>First notification</p>",
+			ReplyTo:     "reply@qweqwe.com",
+			MessageID:   "<status-1@qweqwe.com>",
+			Status:      models.StatusPending,
+			SendAt:      time.Now().Add(1 * time.Hour),
+			UpdatedAt:   time.Now(),
+			SendTo:      []string{"first@qweqwe.com"},
+			CC:          []string{"cc@qweqwe.com"},
+			BCC:         []string{"bcc@qweqwe.com"},
 		},
 		{
 			ID:        fmt.Sprintf("status-2-%d", time.Now().UnixNano()+1),
@@ -420,6 +683,17 @@ func TestGetAllStatuses(t *testing.T) {
 	found := map[string]bool{}
 	for _, n := range allStatuses {
 		found[n.ID] = true
+		if n.ID == notifications[0].ID {
+			expected := notifications[0]
+			if n.Subject != expected.Subject || n.Message != expected.Message ||
+				n.HTMLMessage != expected.HTMLMessage || n.ReplyTo != expected.ReplyTo ||
+				n.MessageID != expected.MessageID || n.Channel != expected.Channel {
+				t.Fatalf("notification fields not round-tripped: %+v", n)
+			}
+			if len(n.SendTo) != 1 || len(n.CC) != 1 || len(n.BCC) != 1 || n.BCC[0] != expected.BCC[0] {
+				t.Fatalf("recipients not round-tripped: %+v", n)
+			}
+		}
 	}
 
 	for _, n := range notifications {
@@ -436,6 +710,676 @@ func TestGetAllStatuses(t *testing.T) {
 
 }
 
+// unpublished returns the number of outbox entries of a notification that have not been published yet.
+func unpublished(t *testing.T, notificationID string) int {
+
+	var count int
+
+	err := testStorage.DB().Master.QueryRowContext(context.Background(),
+		"SELECT COUNT(*) FROM Outbox WHERE notification_uuid = $1 AND published_at IS NULL", notificationID).Scan(&count)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	return count
+
+}
+
+func TestRescheduleNotification(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("reschedule-%d", time.Now().UnixNano()),
+		Channel:   models.Stdout,
+		Message:   "old",
+		Status:    models.StatusLate,
+		SendAt:    time.Now().Add(-time.Minute),
+		UpdatedAt: time.Now(),
+	}
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("CreateNotification failed: %v", err)
+	}
+
+	n.SendAt = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	n.Message = "new"
+	n.Revision = 1
+	n.UpdatedAt = time.Now()
+
+	if err := testStorage.RescheduleNotification(ctx, n); err != nil {
+		t.Fatalf("RescheduleNotification failed: %v", err)
+	}
+
+	got, err := testStorage.GetNotification(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetNotification failed: %v", err)
+	}
+	if got.Revision != 1 || got.Message != "new" || got.Status != models.StatusPending || !got.SendAt.Equal(n.SendAt) {
+		t.Fatalf("rescheduled notification not saved: %+v", got)
+	}
+
+	if count := unpublished(t, n.ID); count != 2 {
+		t.Fatalf("expected an outbox entry for the new revision, got %d unpublished entries", count)
+	}
+
+	if err := testStorage.RescheduleNotification(ctx, n); err != errs.ErrCannotReschedule {
+		t.Fatalf("expected ErrCannotReschedule for a stale revision, got %v", err)
+	}
+
+	if count := unpublished(t, n.ID); count != 2 {
+		t.Fatalf("expected no outbox entry for a rejected reschedule, got %d unpublished entries", count)
+	}
+
+	if err := testStorage.SetStatus(ctx, n.ID, models.StatusSent); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	n.Revision = 2
+	if err := testStorage.RescheduleNotification(ctx, n); err != errs.ErrCannotReschedule {
+		t.Fatalf("expected ErrCannotReschedule for a sent notification, got %v", err)
+	}
+
+	if _, err := testStorage.GetNotification(ctx, "missing"); err != errs.ErrNotificationNotFound {
+		t.Fatalf("expected ErrNotificationNotFound, got %v", err)
+	}
+
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+
+	ctx := context.Background()
+
+	key := models.IdempotencyKey{Key: fmt.Sprintf("key-%d", time.Now().UnixNano()), RequestHash: strings.Repeat("a", 64)}
+
+	if _, err := testStorage.GetIdempotencyKey(ctx, key.Key); err != errs.ErrUnknownIdempotencyKey {
+		t.Fatalf("expected ErrUnknownIdempotencyKey, got %v", err)
+	}
+
+	first := models.Notification{
+		ID:        fmt.Sprintf("idempotent-1-%d", time.Now().UnixNano()),
+		Channel:   models.Stdout,
+		Message:   "once",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(time.Hour),
+		UpdatedAt: time.Now(),
+	}
+
+	stored, err := testStorage.CreateNotificationOnce(ctx, first, key)
+	if err != nil {
+		t.Fatalf("CreateNotificationOnce failed: %v", err)
+	}
+	if stored.NotificationID != first.ID {
+		t.Fatalf("expected key to be bound to %s, got %s", first.ID, stored.NotificationID)
+	}
+
+	second := first
+	second.ID = fmt.Sprintf("idempotent-2-%d", time.Now().UnixNano())
+
+	stored, err = testStorage.CreateNotificationOnce(ctx, second, key)
+	if err != nil {
+		t.Fatalf("CreateNotificationOnce failed: %v", err)
+	}
+	if stored.NotificationID != first.ID || stored.RequestHash != key.RequestHash {
+		t.Fatalf("expected existing binding to %s, got %+v", first.ID, stored)
+	}
+
+	if _, err := testStorage.GetStatus(ctx, second.ID); err != errs.ErrNotificationNotFound {
+		t.Fatalf("expected second notification not to be inserted, got %v", err)
+	}
+
+	got, err := testStorage.GetIdempotencyKey(ctx, key.Key)
+	if err != nil {
+		t.Fatalf("GetIdempotencyKey failed: %v", err)
+	}
+	if got.NotificationID != first.ID {
+		t.Fatalf("expected key to be bound to %s, got %s", first.ID, got.NotificationID)
+	}
+
+	if err := testStorage.DeleteNotification(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteNotification failed: %v", err)
+	}
+
+	if _, err := testStorage.GetIdempotencyKey(ctx, key.Key); err != errs.ErrUnknownIdempotencyKey {
+		t.Fatalf("expected key to be deleted with its notification, got %v", err)
+	}
+
+}
+
+func TestCreateNotifications(t *testing.T) {
+
+	ctx := context.Background()
+
+	var batch []models.Notification
+	expiresAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	for i := range 3 {
+		batch = append(batch, models.Notification{
+			ID:        fmt.Sprintf("batch-%d-%d", i, time.Now().UnixNano()),
+			Channel:   models.Email,
+			Message:   "batch",
+			Subject:   "batch",
+			Status:    models.StatusPending,
+			SendAt:    time.Now().Add(time.Hour),
+			UpdatedAt: time.Now(),
+			SendTo:    []string{fmt.Sprintf("user%d@example.com", i)},
+			Priority:  models.PriorityBulk,
+			ExpiresAt: &expiresAt,
+		})
+	}
+
+	if err := testStorage.CreateNotifications(ctx, batch); err != nil {
+		t.Fatalf("CreateNotifications failed: %v", err)
+	}
+
+	for _, n := range batch {
+		got, err := testStorage.GetNotification(ctx, n.ID)
+		if err != nil {
+			t.Fatalf("GetNotification failed: %v", err)
+		}
+		if len(got.SendTo) != 1 || got.SendTo[0] != n.SendTo[0] {
+			t.Fatalf("recipients not saved for %s: %+v", n.ID, got.SendTo)
+		}
+		if got.Priority != models.PriorityBulk {
+			t.Fatalf("priority not saved for %s: %q", n.ID, got.Priority)
+		}
+		if got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) {
+			t.Fatalf("expires_at not saved for %s: %v", n.ID, got.ExpiresAt)
+		}
+	}
+
+	duplicate := batch[0]
+	fresh := batch[1]
+	fresh.ID = fmt.Sprintf("batch-fresh-%d", time.Now().UnixNano())
+
+	if err := testStorage.CreateNotifications(ctx, []models.Notification{fresh, duplicate}); err == nil {
+		t.Fatalf("expected an error for a duplicate ID")
+	}
+
+	if _, err := testStorage.GetStatus(ctx, fresh.ID); err != errs.ErrNotificationNotFound {
+		t.Fatalf("expected the whole batch to be rolled back, got %v", err)
+	}
+
+}
+
+func TestTags(t *testing.T) {
+
+	ctx := context.Background()
+	tag := fmt.Sprintf("order:%d", time.Now().UnixNano())
+
+	pending := models.Notification{
+		ID:        fmt.Sprintf("tag-pending-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "tagged",
+		Subject:   "tagged",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(time.Hour),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+		Tags:      []string{tag, "campaign"},
+	}
+
+	sent := pending
+	sent.ID = fmt.Sprintf("tag-sent-%d", time.Now().UnixNano())
+	sent.Status = models.StatusSent
+
+	for _, n := range []models.Notification{pending, sent} {
+		if err := testStorage.CreateNotification(ctx, n); err != nil {
+			t.Fatalf("CreateNotification failed: %v", err)
+		}
+	}
+
+	listed, err := testStorage.ListByTag(ctx, tag)
+	if err != nil {
+		t.Fatalf("ListByTag failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(listed))
+	}
+	if len(listed[0].Tags) != 2 {
+		t.Fatalf("tags not saved: %+v", listed[0].Tags)
+	}
+
+	canceled, err := testStorage.CancelByTag(ctx, tag)
+	if err != nil {
+		t.Fatalf("CancelByTag failed: %v", err)
+	}
+	if len(canceled) != 1 || canceled[0] != pending.ID {
+		t.Fatalf("expected only %s to be canceled, got %v", pending.ID, canceled)
+	}
+
+	status, err := testStorage.GetStatus(ctx, sent.ID)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != models.StatusSent {
+		t.Fatalf("expected sent notification to keep its status, got %s", status)
+	}
+
+	canceled, err = testStorage.CancelByTag(ctx, tag)
+	if err != nil {
+		t.Fatalf("CancelByTag failed: %v", err)
+	}
+	if len(canceled) != 0 {
+		t.Fatalf("expected nothing to cancel, got %v", canceled)
+	}
+
+}
+
+func TestDeadLetters(t *testing.T) {
+
+	ctx := context.Background()
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("dead-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "dead",
+		Subject:   "dead",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(-time.Hour),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("CreateNotification failed: %v", err)
+	}
+
+	deadLetter := models.DeadLetter{
+		ID:             helpers.CreateUUID(),
+		NotificationID: n.ID,
+		Reason:         "smtp: mailbox unavailable",
+		Body:           `{"id":"` + n.ID + `"}`,
+		Attempts:       5,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := testStorage.CreateDeadLetter(ctx, deadLetter); err != nil {
+		t.Fatalf("CreateDeadLetter failed: %v", err)
+	}
+
+	got, err := testStorage.GetDeadLetter(ctx, deadLetter.ID)
+	if err != nil {
+		t.Fatalf("GetDeadLetter failed: %v", err)
+	}
+	if got.Reason != deadLetter.Reason || got.Body != deadLetter.Body || got.Attempts != 5 || got.ReplayedAt != nil {
+		t.Fatalf("unexpected dead letter: %+v", got)
+	}
+
+	listed, err := testStorage.ListDeadLetters(ctx)
+	if err != nil {
+		t.Fatalf("ListDeadLetters failed: %v", err)
+	}
+	if !slices.ContainsFunc(listed, func(d models.DeadLetter) bool { return d.ID == deadLetter.ID }) {
+		t.Fatalf("dead letter %s not listed", deadLetter.ID)
+	}
+
+	replay := n
+	replay.Revision = 1
+	replay.SendAt = time.Now()
+
+	if err := testStorage.ReplayDeadLetter(ctx, deadLetter.ID, replay); err != errs.ErrCannotReplay {
+		t.Fatalf("expected ErrCannotReplay for a pending notification, got %v", err)
+	}
+
+	if err := testStorage.SetStatus(ctx, n.ID, models.StatusFailed); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	if err := testStorage.ReplayDeadLetter(ctx, deadLetter.ID, replay); err != nil {
+		t.Fatalf("ReplayDeadLetter failed: %v", err)
+	}
+
+	if count := unpublished(t, n.ID); count != 2 {
+		t.Fatalf("expected an outbox entry for the replayed notification, got %d unpublished entries", count)
+	}
+
+	status, err := testStorage.GetStatus(ctx, n.ID)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != models.StatusPending {
+		t.Fatalf("expected replayed notification to be pending, got %s", status)
+	}
+
+	got, err = testStorage.GetDeadLetter(ctx, deadLetter.ID)
+	if err != nil {
+		t.Fatalf("GetDeadLetter failed: %v", err)
+	}
+	if got.ReplayedAt == nil {
+		t.Fatalf("dead letter not marked as replayed")
+	}
+
+	if _, err := testStorage.GetDeadLetter(ctx, helpers.CreateUUID()); err != errs.ErrDeadLetterNotFound {
+		t.Fatalf("expected ErrDeadLetterNotFound, got %v", err)
+	}
+
+}
+
+func TestOutbox(t *testing.T) {
+
+	ctx := context.Background()
+
+	defer func(limit int) { testStorage.Config().RecoverLimit = limit }(testStorage.Config().RecoverLimit)
+	testStorage.Config().RecoverLimit = 100
+
+	for { // publish the entries left by other tests, so the outbox holds only this test's notification
+		outbox, err := testStorage.ClaimOutbox(ctx, 0)
+		if err != nil {
+			t.Fatalf("ClaimOutbox failed: %v", err)
+		}
+		if len(outbox) == 0 {
+			break
+		}
+		ids := make([]int64, 0, len(outbox))
+		for _, o := range outbox {
+			ids = append(ids, o.ID)
+		}
+		if err := testStorage.MarkPublished(ctx, ids); err != nil {
+			t.Fatalf("MarkPublished failed: %v", err)
+		}
+	}
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("outbox-%d", time.Now().UnixNano()),
+		Channel:   models.Email,
+		Message:   "Test message",
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(1 * time.Hour),
+		UpdatedAt: time.Now(),
+		SendTo:    []string{"user@example.com"},
+	}
+
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("failed to create notification: %v", err)
+	}
+
+	outbox, err := testStorage.ClaimOutbox(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimOutbox failed: %v", err)
+	}
+
+	if len(outbox) != 1 || outbox[0].Notification.ID != n.ID {
+		t.Fatalf("expected only notification %s in outbox, got %d entries", n.ID, len(outbox))
+	}
+	if !slices.Equal(outbox[0].Notification.SendTo, n.SendTo) {
+		t.Fatalf("expected recipients %v, got %v", n.SendTo, outbox[0].Notification.SendTo)
+	}
+
+	claimed, err := testStorage.ClaimOutbox(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimOutbox failed: %v", err)
+	}
+
+	if len(claimed) != 0 {
+		t.Fatalf("expected a claimed entry not to be claimed again, got %d entries", len(claimed))
+	}
+
+	n.SendAt = time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	n.Revision = 1
+	n.UpdatedAt = time.Now()
+
+	// a reschedule committed between claiming and marking adds an entry that must stay unpublished
+	if err := testStorage.RescheduleNotification(ctx, n); err != nil {
+		t.Fatalf("RescheduleNotification failed: %v", err)
+	}
+
+	if err := testStorage.MarkPublished(ctx, []int64{outbox[0].ID}); err != nil {
+		t.Fatalf("MarkPublished failed: %v", err)
+	}
+
+	outbox, err = testStorage.ClaimOutbox(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimOutbox failed: %v", err)
+	}
+
+	if len(outbox) != 1 || outbox[0].Notification.ID != n.ID || outbox[0].Notification.Revision != 1 {
+		t.Fatalf("expected the entry of revision 1 of %s to stay in outbox, got %d entries", n.ID, len(outbox))
+	}
+
+	claimed, err = testStorage.ClaimOutbox(ctx, 0)
+	if err != nil {
+		t.Fatalf("ClaimOutbox failed: %v", err)
+	}
+
+	if len(claimed) != 1 || claimed[0].ID != outbox[0].ID {
+		t.Fatalf("expected an expired claim to be taken over, got %d entries", len(claimed))
+	}
+
+	if err := testStorage.MarkPublished(ctx, []int64{claimed[0].ID}); err != nil {
+		t.Fatalf("MarkPublished failed: %v", err)
+	}
+
+	outbox, err = testStorage.ClaimOutbox(ctx, 0)
+	if err != nil {
+		t.Fatalf("ClaimOutbox failed: %v", err)
+	}
+
+	if len(outbox) != 0 {
+		t.Fatalf("expected published notification %s to leave the outbox, got %d entries", n.ID, len(outbox))
+	}
+
+}
+
+func TestTemplates(t *testing.T) {
+
+	ctx := context.Background()
+
+	name := fmt.Sprintf("template-%d", time.Now().UnixNano())
+	tmpl := models.Template{
+		Name: name,
+		Variants: map[string]models.TemplateVariant{
+			models.DefaultVariant: {Message: "Hi {{.name}}"},
+		},
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	if err := testStorage.CreateTemplate(ctx, tmpl); err != nil {
+		t.Fatalf("CreateTemplate failed: %v", err)
+	}
+
+	if err := testStorage.CreateTemplate(ctx, tmpl); err != errs.ErrTemplateExists {
+		t.Fatalf("expected ErrTemplateExists, got %v", err)
+	}
+
+	tmpl.Variants[models.Email] = models.TemplateVariant{Subject: "Hello", Message: "Hi {{.name}}"}
+	if err := testStorage.UpdateTemplate(ctx, tmpl); err != nil {
+		t.Fatalf("UpdateTemplate failed: %v", err)
+	}
+
+	got, err := testStorage.GetTemplate(ctx, name)
+	if err != nil {
+		t.Fatalf("GetTemplate failed: %v", err)
+	}
+	if len(got.Variants) != 2 || got.Variants[models.Email].Subject != "Hello" {
+		t.Fatalf("template variants not round-tripped: %+v", got)
+	}
+
+	list, err := testStorage.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("ListTemplates failed: %v", err)
+	}
+	if len(list) == 0 {
+		t.Fatalf("expected at least one template")
+	}
+
+	n := models.Notification{
+		ID:        fmt.Sprintf("templated-%d", time.Now().UnixNano()),
+		Channel:   models.Telegram,
+		Template:  name,
+		Params:    map[string]any{"name": "Neo"},
+		Status:    models.StatusPending,
+		SendAt:    time.Now().Add(1 * time.Hour),
+		UpdatedAt: time.Now(),
+	}
+	if err := testStorage.CreateNotification(ctx, n); err != nil {
+		t.Fatalf("CreateNotification failed: %v", err)
+	}
+
+	if err := testStorage.DeleteTemplate(ctx, name); err != errs.ErrTemplateInUse {
+		t.Fatalf("expected ErrTemplateInUse, got %v", err)
+	}
+
+	recovered, err := testStorage.Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	for _, r := range recovered {
+		if r.ID == n.ID && (r.Template != name || r.Params["name"] != "Neo") {
+			t.Fatalf("template fields not round-tripped: %+v", r)
+		}
+	}
+
+	if err := testStorage.SetStatus(ctx, n.ID, models.StatusCanceled); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	schedule := models.Schedule{
+		ID:           fmt.Sprintf("templated-schedule-%d", now.UnixNano()),
+		Cron:         "@daily",
+		TimeZone:     "UTC",
+		StartAt:      now,
+		Status:       models.ScheduleActive,
+		Occurrences:  1,
+		Notification: models.Notification{Channel: models.Telegram, Template: name, Params: map[string]any{"name": "Neo"}},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	occurrence := models.Notification{
+		ID:         fmt.Sprintf("templated-occurrence-%d", now.UnixNano()),
+		ScheduleID: schedule.ID,
+		Channel:    models.Stdout,
+		Message:    "rendered elsewhere",
+		Status:     models.StatusPending,
+		SendAt:     now.Add(time.Hour),
+		UpdatedAt:  now,
+	}
+	schedule.CurrentID = occurrence.ID
+	schedule.NextAt = occurrence.SendAt
+
+	if err := testStorage.CreateSchedule(ctx, schedule, occurrence); err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	if err := testStorage.DeleteTemplate(ctx, name); err != errs.ErrTemplateInUse {
+		t.Fatalf("expected ErrTemplateInUse for a template used by an active schedule, got %v", err)
+	}
+
+	if _, err := testStorage.CancelSchedule(ctx, schedule.ID); err != nil {
+		t.Fatalf("CancelSchedule failed: %v", err)
+	}
+
+	if err := testStorage.DeleteTemplate(ctx, name); err != nil {
+		t.Fatalf("DeleteTemplate failed: %v", err)
+	}
+
+	if _, err := testStorage.GetTemplate(ctx, name); err != errs.ErrTemplateNotFound {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+
+	if err := testStorage.DeleteTemplate(ctx, name); err != errs.ErrTemplateNotFound {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+
+}
+
+func TestSchedules(t *testing.T) {
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	schedule := models.Schedule{
+		ID:           fmt.Sprintf("schedule-%d", now.UnixNano()),
+		Cron:         "@daily",
+		TimeZone:     "UTC",
+		StartAt:      now,
+		Status:       models.ScheduleActive,
+		Occurrences:  1,
+		Notification: models.Notification{Channel: models.Stdout, Message: "daily"},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	first := models.Notification{
+		ID:         fmt.Sprintf("occurrence-1-%d", now.UnixNano()),
+		ScheduleID: schedule.ID,
+		Channel:    models.Stdout,
+		Message:    "daily",
+		Status:     models.StatusPending,
+		SendAt:     now.Add(time.Hour),
+		UpdatedAt:  now,
+	}
+	schedule.CurrentID = first.ID
+	schedule.NextAt = first.SendAt
+
+	if err := testStorage.CreateSchedule(ctx, schedule, first); err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	got, err := testStorage.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if got.CurrentID != first.ID || got.Cron != "@daily" || got.Notification.Message != "daily" || got.EndAt != nil {
+		t.Fatalf("schedule not round-tripped: %+v", got)
+	}
+
+	if err := testStorage.SetStatus(ctx, first.ID, models.StatusSent); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	stale, err := testStorage.StaleSchedules(ctx)
+	if err != nil {
+		t.Fatalf("StaleSchedules failed: %v", err)
+	}
+	found := false
+	for _, s := range stale {
+		found = found || s.ID == schedule.ID
+	}
+	if !found {
+		t.Fatalf("expected schedule %s to be stale", schedule.ID)
+	}
+
+	second := first
+	second.ID = fmt.Sprintf("occurrence-2-%d", now.UnixNano())
+	second.SendAt = first.SendAt.Add(24 * time.Hour)
+
+	if err := testStorage.AdvanceSchedule(ctx, schedule.ID, first.ID, &second); err != nil {
+		t.Fatalf("AdvanceSchedule failed: %v", err)
+	}
+
+	if err := testStorage.AdvanceSchedule(ctx, schedule.ID, first.ID, &second); err != errs.ErrScheduleNotActive {
+		t.Fatalf("expected ErrScheduleNotActive, got %v", err)
+	}
+
+	canceled, err := testStorage.CancelSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("CancelSchedule failed: %v", err)
+	}
+	if canceled != second.ID {
+		t.Fatalf("expected canceled occurrence %s, got %s", second.ID, canceled)
+	}
+
+	status, err := testStorage.GetStatus(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != models.StatusCanceled {
+		t.Fatalf("expected occurrence to be canceled, got %s", status)
+	}
+
+	if _, err := testStorage.CancelSchedule(ctx, schedule.ID); err != errs.ErrScheduleNotActive {
+		t.Fatalf("expected ErrScheduleNotActive, got %v", err)
+	}
+
+	if _, err := testStorage.CancelSchedule(ctx, "missing"); err != errs.ErrScheduleNotFound {
+		t.Fatalf("expected ErrScheduleNotFound, got %v", err)
+	}
+
+}
+
 func TestClose(t *testing.T) {
 	log, _ := logger.NewLogger(config.Logger{Debug: true})
 	db, _ := dbpg.New(fmt.Sprintf("host=localhost port=5434 user=%s password=%s dbname=chronos_test sslmode=disable",
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// ReplayDeadLetter makes the notification of a dead letter pending again with a fresh set of
+// delivery attempts and marks the dead letter as replayed, in one transaction, together with an outbox
+// entry from which the broker relay publishes the replayed notification. The notification
+// is saved with its new send time, deadline and revision; the update only applies if it is still
+// "failed to send" or "partially sent" and has not been changed since it was read, i.e. its stored
+// revision is the one preceding notification.Revision; otherwise ErrCannotReplay is returned.
+func (s *Storage) ReplayDeadLetter(ctx context.Context, deadLetterID string, notification models.Notification) error {
+
+	notificationQuery := `
+
+	UPDATE Notifications
+	SET send_at = $1, send_at_local = $2, expires_at = $3, revision = $4, attempts = 0, available_at = NULL, status = $5, updated_at = $6
+	WHERE uuid = $7 AND revision = $8 AND status IN ($9, $10);`
+
+	deadLetterQuery := `
+
+	UPDATE DeadLetters
+	SET replayed_at = NOW()
+	WHERE uuid = $1;`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var replayed bool
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		replayed = false
+
+		res, err := tx.ExecContext(ctx, notificationQuery,
+			notification.SendAt, notification.SendAtLocal, notification.ExpiresAt, notification.Revision,
+			models.StatusPending, notification.UpdatedAt, notification.ID, notification.Revision-1,
+			models.StatusFailed, models.StatusPartiallySent)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get number of affected rows: %w", err)
+		}
+
+		if rows == 0 {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, deadLetterQuery, deadLetterID); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		if err := insertOutbox(ctx, tx, notification.ID); err != nil {
+			return err
+		}
+
+		replayed = true
+
+		return nil
+
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !replayed {
+		return errs.ErrCannotReplay
+	}
+
+	return nil
+
+}
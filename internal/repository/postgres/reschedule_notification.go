@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// RescheduleNotification saves the new send time, deadline, message and revision of a notification
+// and makes it pending again with a fresh set of delivery attempts. The update only applies if the
+// notification is still "pending" or "running late" and has not been rescheduled since it was read,
+// i.e. its stored revision is the one preceding notification.Revision; otherwise ErrCannotReschedule is returned.
+// The update is saved together with an outbox entry, from which the broker relay publishes the new revision.
+func (s *Storage) RescheduleNotification(ctx context.Context, notification models.Notification) error {
+
+	query := `
+
+	UPDATE Notifications
+	SET send_at = $1, send_at_local = $2, expires_at = $3, message = $4, revision = $5, attempts = 0, available_at = NULL, status = $6, updated_at = $7
+	WHERE uuid = $8 AND revision = $9 AND status IN ($10, $11);`
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	var rescheduled bool
+
+	err := s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		rescheduled = false
+
+		res, err := tx.ExecContext(ctx, query,
+			notification.SendAt, notification.SendAtLocal, notification.ExpiresAt, notification.Message, notification.Revision,
+			models.StatusPending, notification.UpdatedAt, notification.ID, notification.Revision-1,
+			models.StatusPending, models.StatusLate)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get number of affected rows: %w", err)
+		}
+
+		if rows == 0 {
+			return nil
+		}
+
+		if err := insertOutbox(ctx, tx, notification.ID); err != nil {
+			return err
+		}
+
+		rescheduled = true
+
+		return nil
+
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !rescheduled {
+		return errs.ErrCannotReschedule
+	}
+
+	return nil
+
+}
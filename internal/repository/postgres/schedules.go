@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// selectSchedules selects every persisted field of a schedule.
+// Callers append their own WHERE and ORDER BY clauses and scan rows with scanSchedule.
+const selectSchedules = `
+
+		SELECT s.uuid, s.cron, s.rrule, s.time_zone, s.start_at, s.end_at, s.max_occurrences, s.max_delay,
+		s.occurrences, s.status, s.current_uuid, s.next_at, s.notification, s.created_at, s.updated_at
+		FROM Schedules s`
+
+// scanSchedule reads a single row produced by a query built on selectSchedules.
+func scanSchedule(row interface{ Scan(dest ...any) error }) (models.Schedule, error) {
+
+	var schedule models.Schedule
+	var endAt sql.NullTime
+	var notification []byte
+
+	if err := row.Scan(&schedule.ID, &schedule.Cron, &schedule.RRule, &schedule.TimeZone,
+		&schedule.StartAt, &endAt, &schedule.MaxOccurrences, &schedule.MaxDelay, &schedule.Occurrences, &schedule.Status,
+		&schedule.CurrentID, &schedule.NextAt, &notification, &schedule.CreatedAt, &schedule.UpdatedAt); err != nil {
+		return models.Schedule{}, err
+	}
+
+	if endAt.Valid {
+		schedule.EndAt = &endAt.Time
+	}
+
+	if err := json.Unmarshal(notification, &schedule.Notification); err != nil {
+		return models.Schedule{}, fmt.Errorf("failed to unmarshal schedule notification: %w", err)
+	}
+
+	return schedule, nil
+
+}
+
+// scanSchedules reads all rows produced by a query built on selectSchedules.
+func scanSchedules(rows *sql.Rows) ([]models.Schedule, error) {
+
+	var schedules []models.Schedule
+
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return schedules, nil
+
+}
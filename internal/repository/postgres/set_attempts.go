@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// SetAttempts records the number of delivery attempts made for a notification so far.
+// The counter travels with the queued message as well; the stored one is what recovery
+// re-queues the notification with, so a restart does not grant it a fresh set of retries.
+func (s *Storage) SetAttempts(ctx context.Context, notificationID string, attempts int) error {
+
+	query := `
+
+	UPDATE Notifications
+	SET attempts = $1, updated_at = NOW()
+	WHERE uuid = $2;`
+
+	res, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, attempts, notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errs.ErrNotificationNotFound
+	}
+
+	return nil
+
+}
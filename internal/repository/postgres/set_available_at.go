@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// SetAvailableAt sets the time before which a notification is not claimed by ClaimDue,
+// which is how the Postgres broker backend delays the next delivery attempt.
+// The send time itself is left unchanged, so lateness and expiry are still measured from it.
+func (s *Storage) SetAvailableAt(ctx context.Context, notificationID string, availableAt time.Time) error {
+
+	query := `
+
+	UPDATE Notifications
+	SET available_at = $1
+	WHERE uuid = $2;`
+
+	res, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, availableAt, notificationID)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return errs.ErrNotificationNotFound
+	}
+
+	return nil
+
+}
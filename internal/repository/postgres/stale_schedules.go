@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// StaleSchedules returns active schedules whose current occurrence is no longer pending, late or sending,
+// or no longer exists. Such schedules were interrupted between processing an occurrence and
+// producing the next one, and are advanced again during recovery.
+func (s *Storage) StaleSchedules(ctx context.Context) ([]models.Schedule, error) {
+
+	query := selectSchedules + `
+		LEFT JOIN Notifications n ON n.uuid = s.current_uuid
+		WHERE s.status = $1 AND (n.uuid IS NULL OR n.status NOT IN ($2, $3, $4))
+		ORDER BY s.next_at ASC
+		LIMIT $5;`
+
+	rows, err := s.db.QueryWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query,
+		models.ScheduleActive, models.StatusPending, models.StatusLate, models.StatusSending, s.config.RecoverLimit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanSchedules(rows)
+
+}
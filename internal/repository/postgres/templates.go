@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// selectTemplates selects every persisted field of a template.
+// Callers append their own WHERE and ORDER BY clauses and scan rows with scanTemplate.
+const selectTemplates = `
+
+		SELECT name, variants, created_at, updated_at
+		FROM Templates`
+
+// scanTemplate reads a single row produced by a query built on selectTemplates.
+func scanTemplate(row interface{ Scan(dest ...any) error }) (models.Template, error) {
+
+	var tmpl models.Template
+	var variants []byte
+
+	if err := row.Scan(&tmpl.Name, &variants, &tmpl.CreatedAt, &tmpl.UpdatedAt); err != nil {
+		return models.Template{}, err
+	}
+
+	if err := json.Unmarshal(variants, &tmpl.Variants); err != nil {
+		return models.Template{}, fmt.Errorf("failed to unmarshal template variants: %w", err)
+	}
+
+	return tmpl, nil
+
+}
+
+// scanTemplates reads all rows produced by a query built on selectTemplates.
+func scanTemplates(rows *sql.Rows) ([]models.Template, error) {
+
+	var templates []models.Template
+
+	for rows.Next() {
+		tmpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		templates = append(templates, tmpl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return templates, nil
+
+}
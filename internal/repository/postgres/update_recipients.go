@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// UpdateRecipients saves the delivery state of the given recipients of a notification in one transaction.
+// Recipients are matched by address, so an address listed more than once is updated everywhere.
+func (s *Storage) UpdateRecipients(ctx context.Context, notificationID string, recipients []models.Recipient) error {
+
+	strategy := retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff,
+	}
+
+	query := `
+
+	UPDATE Recipients
+	SET status = $1, attempts = $2, last_error = $3, delivered_at = $4
+	WHERE notification_uuid = $5 AND recipient = $6;`
+
+	return s.db.WithTxWithRetry(ctx, strategy, func(tx *sql.Tx) error {
+
+		for _, r := range recipients {
+			if _, err := tx.ExecContext(ctx, query,
+				r.Status, r.Attempts, r.LastError, r.DeliveredAt, notificationID, r.Address); err != nil {
+				return fmt.Errorf("failed to execute query: %w", err)
+			}
+		}
+
+		return nil
+
+	})
+
+}
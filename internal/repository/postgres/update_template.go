@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wb-go/wbf/retry"
+)
+
+// UpdateTemplate replaces the variants of an existing template.
+// Notifications that use the template are rendered with the new variants when they are sent.
+func (s *Storage) UpdateTemplate(ctx context.Context, tmpl models.Template) error {
+
+	variants, err := json.Marshal(tmpl.Variants)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template variants: %w", err)
+	}
+
+	query := `
+
+	UPDATE Templates
+	SET variants = $1, updated_at = $2
+	WHERE name = $3;`
+
+	result, err := s.db.ExecWithRetry(ctx, retry.Strategy{
+		Attempts: s.config.QueryRetryStrategy.Attempts,
+		Delay:    s.config.QueryRetryStrategy.Delay,
+		Backoff:  s.config.QueryRetryStrategy.Backoff}, query, variants, tmpl.UpdatedAt, tmpl.Name)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errs.ErrTemplateNotFound
+	}
+
+	return nil
+
+}
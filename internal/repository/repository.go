@@ -9,6 +9,7 @@ import (
 	"Chronos/internal/repository/postgres"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/wb-go/wbf/dbpg"
 )
@@ -17,15 +18,44 @@ import (
 // It abstracts database operations such as creating notifications, retrieving their status,
 // marking late notifications, and performing cleanup.
 type Storage interface {
-	CreateNotification(ctx context.Context, notification models.Notification) error // CreateNotification inserts a new notification into the storage.
-	DeleteNotification(ctx context.Context, notificationID string) error            // DeleteNotification removes a notification by its ID.
-	GetStatus(ctx context.Context, notificationID string) (string, error)           // GetStatus returns the current status of a notification by its ID.
-	GetAllStatuses(ctx context.Context) ([]models.Notification, error)              // GetAllStatuses returns all notifications and their statuses.
-	SetStatus(ctx context.Context, notificationID string, status string) error      // SetStatus updates the status of a notification.
-	MarkLates(ctx context.Context) ([]string, error)                                // MarkLates marks notifications that are late in the database and returns their IDs.
-	Recover(ctx context.Context) ([]models.Notification, error)                     // Recover returns pending or late notifications for re-queuing.
-	Cleanup(ctx context.Context)                                                    // Cleanup performs periodic cleanup tasks, such as removing expired notifications.
-	Close()                                                                         // Close closes the storage connection.
+	CreateNotification(ctx context.Context, notification models.Notification) error                                                         // CreateNotification inserts a new notification into the storage.
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error                                                     // CreateNotifications inserts a batch of notifications in a single transaction.
+	CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (models.IdempotencyKey, error) // CreateNotificationOnce inserts a notification bound to an idempotency key, or returns the key's existing binding.
+	GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyKey, error)                                                       // GetIdempotencyKey returns the unexpired binding of an idempotency key.
+	DeleteNotification(ctx context.Context, notificationID string) error                                                                    // DeleteNotification removes a notification by its ID.
+	GetNotification(ctx context.Context, notificationID string) (models.Notification, error)                                                // GetNotification returns every persisted field of a notification by its ID.
+	RescheduleNotification(ctx context.Context, notification models.Notification) error                                                     // RescheduleNotification saves the new send time, message and revision of a pending or late notification.
+	GetStatus(ctx context.Context, notificationID string) (string, error)                                                                   // GetStatus returns the current status of a notification by its ID.
+	GetAllStatuses(ctx context.Context) ([]models.Notification, error)                                                                      // GetAllStatuses returns all notifications and their statuses.
+	SetStatus(ctx context.Context, notificationID string, status string) error                                                              // SetStatus updates the status of a notification.
+	SetAttempts(ctx context.Context, notificationID string, attempts int) error                                                             // SetAttempts records the number of delivery attempts made for a notification.
+	ClaimNotification(ctx context.Context, notificationID string, revision int, lease time.Duration) (string, error)                        // ClaimNotification moves a pending or late notification to sending and returns its previous status.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error)                                            // ClaimDue moves due notifications to sending and returns them with their previous status.
+	SetAvailableAt(ctx context.Context, notificationID string, availableAt time.Time) error                                                 // SetAvailableAt delays the next claim of a notification by ClaimDue.
+	CreateDeadLetter(ctx context.Context, deadLetter models.DeadLetter) error                                                               // CreateDeadLetter saves a message that could not be delivered.
+	ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error)                                                                       // ListDeadLetters returns the dead letters that have not been replayed yet.
+	GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error)                                                      // GetDeadLetter returns a dead letter by ID.
+	ReplayDeadLetter(ctx context.Context, deadLetterID string, notification models.Notification) error                                      // ReplayDeadLetter makes the notification of a dead letter pending again and marks the dead letter as replayed.
+	GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error)                                                   // GetRecipients returns the delivery state of every recipient of a notification.
+	UpdateRecipients(ctx context.Context, notificationID string, recipients []models.Recipient) error                                       // UpdateRecipients saves the delivery state of the given recipients.
+	CreateTemplate(ctx context.Context, tmpl models.Template) error                                                                         // CreateTemplate inserts a new template into the storage.
+	GetTemplate(ctx context.Context, name string) (models.Template, error)                                                                  // GetTemplate returns a template by its name.
+	ListTemplates(ctx context.Context) ([]models.Template, error)                                                                           // ListTemplates returns all templates.
+	UpdateTemplate(ctx context.Context, tmpl models.Template) error                                                                         // UpdateTemplate replaces the variants of a template.
+	DeleteTemplate(ctx context.Context, name string) error                                                                                  // DeleteTemplate removes a template unless scheduled notifications use it.
+	CreateSchedule(ctx context.Context, schedule models.Schedule, first models.Notification) error                                          // CreateSchedule inserts a recurring schedule together with its first occurrence.
+	GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error)                                                            // GetSchedule returns a recurring schedule by its ID.
+	AdvanceSchedule(ctx context.Context, scheduleID string, currentID string, next *models.Notification) error                              // AdvanceSchedule replaces the current occurrence of a schedule with the next one, or finishes the schedule if next is nil.
+	CancelSchedule(ctx context.Context, scheduleID string) (string, error)                                                                  // CancelSchedule cancels a schedule and its pending occurrence, returning the canceled occurrence ID.
+	StaleSchedules(ctx context.Context) ([]models.Schedule, error)                                                                          // StaleSchedules returns active schedules whose current occurrence has already been processed.
+	ListByTag(ctx context.Context, tag string) ([]models.Notification, error)                                                               // ListByTag returns every notification carrying the tag.
+	CancelByTag(ctx context.Context, tag string) ([]string, error)                                                                          // CancelByTag cancels the pending or late notifications carrying the tag and returns their IDs.
+	MarkLates(ctx context.Context) ([]string, error)                                                                                        // MarkLates marks notifications that are late in the database and returns their IDs.
+	Recover(ctx context.Context) ([]models.Notification, error)                                                                             // Recover returns pending or late notifications for re-queuing.
+	ClaimOutbox(ctx context.Context, lease time.Duration) ([]models.OutboxEntry, error)                                                     // ClaimOutbox claims the outbox entries that have not been published yet and returns them with their notifications.
+	MarkPublished(ctx context.Context, entryIDs []int64) error                                                                              // MarkPublished marks the given outbox entries as published.
+	Cleanup(ctx context.Context)                                                                                                            // Cleanup performs periodic cleanup tasks, such as removing expired notifications.
+	Close()                                                                                                                                 // Close closes the storage connection.
 }
 
 // NewStorage creates a new Storage instance backed by Postgres.
@@ -0,0 +1,31 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// CancelByTag cancels every pending or running late notification carrying the given tag
+// and returns the IDs of the canceled ones. Notifications in other states are skipped,
+// so canceling a tag twice is harmless. The cache is updated for every canceled notification.
+func (s *Service) CancelByTag(ctx context.Context, tag string) ([]string, error) {
+
+	if err := validateTags([]string{tag}); err != nil {
+		return nil, err
+	}
+
+	canceled, err := s.storage.CancelByTag(ctx, tag)
+	if err != nil {
+		s.logger.LogError("service — failed to cancel notifications by tag", err, "tag", tag, "layer", "service.impl")
+		return nil, err
+	}
+
+	for _, id := range canceled {
+		if err := s.cache.SetStatus(ctx, id, models.StatusCanceled); err != nil {
+			s.logger.LogError("service — failed to set notification status in cache", err, "layer", "service.impl")
+		}
+	}
+
+	return canceled, nil
+
+}
@@ -0,0 +1,29 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// CancelSchedule cancels a recurring schedule, so no further occurrences are produced.
+// The pending occurrence, if any, is canceled as well and its cached status is updated.
+// Occurrences that have already been sent keep their status.
+func (s *Service) CancelSchedule(ctx context.Context, scheduleID string) error {
+
+	canceledID, err := s.storage.CancelSchedule(ctx, scheduleID)
+	if err != nil {
+		s.logger.LogError("service — failed to cancel schedule in DB", err, "scheduleID", scheduleID, "layer", "service.impl")
+		return err
+	}
+
+	if canceledID == "" {
+		return nil
+	}
+
+	if err := s.cache.SetStatus(ctx, canceledID, models.StatusCanceled); err != nil {
+		s.logger.LogError("service — failed to set notification status in cache", err, "notificationID", canceledID, "layer", "service.impl")
+	}
+
+	return nil
+
+}
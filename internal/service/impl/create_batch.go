@@ -0,0 +1,61 @@
+package impl
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"context"
+)
+
+// CreateBatch validates every notification of a batch on its own and stores the valid ones in a single
+// transaction, from which they are enqueued through the outbox as in CreateNotification. The result holds
+// one entry per notification, in order: the ID of the created notification or the error that prevented
+// its creation. Invalid notifications do not affect the rest of the batch, while a storage failure fails
+// the whole batch.
+func (s *Service) CreateBatch(ctx context.Context, notifications []models.Notification) ([]models.BatchResult, error) {
+
+	if len(notifications) == 0 || len(notifications) > models.MaxBatchSize {
+		return nil, errs.ErrInvalidBatchSize
+	}
+
+	results := make([]models.BatchResult, len(notifications))
+	valid := make([]models.Notification, 0, len(notifications))
+	positions := make([]int, 0, len(notifications))
+
+	for i, notification := range notifications {
+
+		var err error
+		if notification.Template != "" {
+			err = s.validateTemplated(ctx, &notification)
+		} else {
+			err = validateCreate(&notification, s.notifier)
+		}
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		initialize(&notification)
+		notifier.Prepare(s.notifier, &notification)
+
+		valid = append(valid, notification)
+		positions = append(positions, i)
+
+	}
+
+	if len(valid) == 0 {
+		return results, nil
+	}
+
+	if err := s.storage.CreateNotifications(ctx, valid); err != nil {
+		s.logger.LogError("service — failed to create notifications", err, "count", len(valid), "layer", "service.impl")
+		return nil, err
+	}
+
+	for j, notification := range valid {
+		results[positions[j]].ID = notification.ID
+	}
+
+	return results, nil
+
+}
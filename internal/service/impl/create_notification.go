@@ -3,55 +3,149 @@ package impl
 import (
 	"Chronos/internal/errs"
 	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/templates"
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/wb-go/wbf/helpers"
 )
 
-const brokerRecoveryWindow = time.Hour
 const localDateTime = "2006-01-02 15:04:05"
 
-// CreateNotification validates, initializes, stores, and enqueues a notification.
-// If the broker fails and the notification is scheduled soon (within brokerRecoveryWindow),
-// it will be removed from storage and an ErrUrgentDeliveryFailed is returned.
+// CreateNotification validates, initializes and stores a notification. It is stored together with
+// an outbox entry, from which the broker's relay enqueues it, so a broker outage delays the
+// notification instead of failing the request.
 func (s *Service) CreateNotification(ctx context.Context, notification models.Notification) (string, error) {
+	return s.create(ctx, notification, nil)
+}
+
+// CreateNotificationOnce creates a notification like CreateNotification, unless the idempotency key
+// has already been used. A repeated request with the same body returns the ID of the notification
+// created the first time, while a different body is rejected with ErrIdempotencyKeyReused.
+// The key is checked before validation, so a retry succeeds even if its send_at has passed meanwhile.
+func (s *Service) CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (string, error) {
 
-	if err := validateCreate(&notification); err != nil {
+	if err := validateIdempotencyKey(key.Key); err != nil {
+		return "", err
+	}
+
+	stored, err := s.storage.GetIdempotencyKey(ctx, key.Key)
+	if err == nil {
+		return replay(stored, key)
+	}
+	if !errors.Is(err, errs.ErrUnknownIdempotencyKey) {
+		s.logger.LogError("service — failed to get idempotency key from DB", err, "layer", "service.impl")
+		return "", err
+	}
+
+	return s.create(ctx, notification, &key)
+
+}
+
+// create is shared by CreateNotification and CreateNotificationOnce. With a non-nil key the notification
+// is stored together with the key, and a concurrent request that bound the key first wins.
+func (s *Service) create(ctx context.Context, notification models.Notification, key *models.IdempotencyKey) (string, error) {
+
+	var err error
+	if notification.Template != "" {
+		err = s.validateTemplated(ctx, &notification)
+	} else {
+		err = validateCreate(&notification, s.notifier)
+	}
+	if err != nil {
 		return "", err
 	}
 
 	initialize(&notification)
+	notifier.Prepare(s.notifier, &notification)
+
+	if key == nil {
+		err = s.storage.CreateNotification(ctx, notification)
+	} else {
+		var stored models.IdempotencyKey
+		stored, err = s.storage.CreateNotificationOnce(ctx, notification, *key)
+		if err == nil && stored.NotificationID != notification.ID {
+			return replay(stored, *key)
+		}
+	}
 
-	if err := s.storage.CreateNotification(ctx, notification); err != nil {
+	if err != nil {
 		s.logger.LogError("service — failed to create notification", err, "layer", "service.impl")
 		return "", err
 	}
 
-	if err := s.broker.Produce(notification); err != nil {
+	return notification.ID, nil
 
-		s.logger.LogError("service — failed to produce notification", err, "layer", "service.impl")
+}
+
+// replay resolves a request whose idempotency key is already bound to a notification:
+// the same request gets the bound notification's ID, a different one ErrIdempotencyKeyReused.
+func replay(stored models.IdempotencyKey, key models.IdempotencyKey) (string, error) {
+	if stored.RequestHash != key.RequestHash {
+		return "", errs.ErrIdempotencyKeyReused
+	}
+	return stored.NotificationID, nil
+}
 
-		if time.Until(notification.SendAt) < brokerRecoveryWindow {
+// validateTemplated validates a notification whose message comes from a template.
+// The template is rendered once here so that missing params and oversized messages are
+// reported to the caller; the stored notification keeps only the template name and params
+// and is rendered again by the consumer when it is sent.
+func (s *Service) validateTemplated(ctx context.Context, notification *models.Notification) error {
 
-			err = s.storage.DeleteNotification(ctx, notification.ID)
-			if err != nil {
-				s.logger.LogError("service — failed to delete notification from db", err, "layer", "service.impl")
-			}
-			return "", errs.ErrUrgentDeliveryFailed
+	if notification.Message != "" || notification.HTMLMessage != "" {
+		return errs.ErrTemplateWithMessage
+	}
+
+	if _, err := validateChannel(notification, s.notifier); err != nil {
+		return err
+	}
 
+	tmpl, err := s.storage.GetTemplate(ctx, notification.Template)
+	if err != nil {
+		if errors.Is(err, errs.ErrTemplateNotFound) {
+			return errs.ErrUnknownTemplate
 		}
+		s.logger.LogError("service — failed to get template from DB", err, "template", notification.Template, "layer", "service.impl")
+		return err
+	}
 
+	rendered := *notification
+	if err := templates.Render(tmpl, &rendered); err != nil {
+		return fmt.Errorf("%w: %v", errs.ErrTemplateRender, err)
 	}
 
-	return notification.ID, nil
+	if err := validateCreate(&rendered, s.notifier); err != nil {
+		return err
+	}
+
+	notification.Template = tmpl.Name
+	notification.Priority = rendered.Priority
+
+	return nil
 
 }
 
 // initialize sets the notification ID, status, updated timestamp, and local send time.
+// The send time is stored in UTC once the local one has been rendered in the caller's zone.
 func initialize(notification *models.Notification) {
 	notification.UpdatedAt = time.Now().UTC()
-	notification.SendAtLocal = notification.SendAt.Local().Format(localDateTime)
+	notification.SendAtLocal = localTime(notification.SendAt, notification.TimeZone)
+	notification.SendAt = notification.SendAt.UTC()
 	notification.ID = helpers.CreateUUID()
 	notification.Status = models.StatusPending
 }
+
+// localTime formats t in the given IANA time zone, or in t's own location if the zone is empty,
+// which keeps the UTC offset the caller sent.
+func localTime(t time.Time, zone string) string {
+	if zone != "" {
+		if loc, err := time.LoadLocation(zone); err == nil {
+			t = t.In(loc)
+		}
+	}
+	return t.Format(localDateTime)
+}
@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/recurrence"
+	"context"
+	"time"
+
+	"github.com/wb-go/wbf/helpers"
+)
+
+// CreateSchedule validates a recurring schedule and stores it together with its first occurrence,
+// which is enqueued through the outbox as in CreateNotification. Later occurrences are stored by
+// the consumer, one at a time, after the previous one has been processed.
+func (s *Service) CreateSchedule(ctx context.Context, schedule models.Schedule) (string, error) {
+
+	now := time.Now().UTC()
+
+	sendAt, err := validateSchedule(&schedule, now)
+	if err != nil {
+		return "", err
+	}
+
+	blueprint := schedule.Notification
+	blueprint.SendAt = sendAt
+
+	if blueprint.Template != "" {
+		err = s.validateTemplated(ctx, &blueprint)
+	} else {
+		err = validateCreate(&blueprint, s.notifier)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blueprint.SendAt = time.Time{}
+
+	schedule.ID = helpers.CreateUUID()
+	schedule.Notification = blueprint
+	schedule.Status = models.ScheduleActive
+	schedule.Occurrences = 1
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	first := recurrence.Occurrence(schedule, sendAt)
+	notifier.Prepare(s.notifier, &first)
+
+	schedule.CurrentID = first.ID
+	schedule.NextAt = first.SendAt
+
+	if err := s.storage.CreateSchedule(ctx, schedule, first); err != nil {
+		s.logger.LogError("service — failed to create schedule", err, "layer", "service.impl")
+		return "", err
+	}
+
+	return schedule.ID, nil
+
+}
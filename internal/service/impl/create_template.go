@@ -0,0 +1,27 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+)
+
+// CreateTemplate validates and stores a new template.
+// Template syntax is checked up front so that broken templates never reach the consumer.
+func (s *Service) CreateTemplate(ctx context.Context, tmpl models.Template) error {
+
+	if err := validateTemplate(&tmpl, s.notifier); err != nil {
+		return err
+	}
+
+	tmpl.CreatedAt = time.Now().UTC()
+	tmpl.UpdatedAt = tmpl.CreatedAt
+
+	if err := s.storage.CreateTemplate(ctx, tmpl); err != nil {
+		s.logger.LogError("service — failed to create template", err, "template", tmpl.Name, "layer", "service.impl")
+		return err
+	}
+
+	return nil
+
+}
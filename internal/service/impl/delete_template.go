@@ -0,0 +1,18 @@
+package impl
+
+import (
+	"context"
+)
+
+// DeleteTemplate deletes a template by its name.
+// Templates used by pending or late notifications cannot be deleted.
+func (s *Service) DeleteTemplate(ctx context.Context, name string) error {
+
+	if err := s.storage.DeleteTemplate(ctx, name); err != nil {
+		s.logger.LogError("service — failed to delete template", err, "template", name, "layer", "service.impl")
+		return err
+	}
+
+	return nil
+
+}
@@ -0,0 +1,23 @@
+package impl
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"errors"
+)
+
+// GetDeadLetter returns a dead letter by its ID, including the raw message body.
+func (s *Service) GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error) {
+
+	deadLetter, err := s.storage.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrDeadLetterNotFound) {
+			s.logger.LogError("service — failed to get dead letter from DB", err, "deadLetterID", deadLetterID, "layer", "service.impl")
+		}
+		return models.DeadLetter{}, err
+	}
+
+	return deadLetter, nil
+
+}
@@ -0,0 +1,20 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// GetRecipients returns the delivery state of every recipient of a notification.
+// Recipient states change on every delivery attempt, so they are always read from the database.
+func (s *Service) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+
+	recipients, err := s.storage.GetRecipients(ctx, notificationID)
+	if err != nil {
+		s.logger.LogError("service — failed to get notification recipients from DB", err, "notificationID", notificationID, "layer", "service.impl")
+		return nil, err
+	}
+
+	return recipients, nil
+
+}
@@ -0,0 +1,19 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// GetSchedule returns a recurring schedule by its ID.
+func (s *Service) GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error) {
+
+	schedule, err := s.storage.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		s.logger.LogError("service — failed to get schedule from DB", err, "scheduleID", scheduleID, "layer", "service.impl")
+		return models.Schedule{}, err
+	}
+
+	return schedule, nil
+
+}
@@ -0,0 +1,19 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// GetTemplate returns a template by its name.
+func (s *Service) GetTemplate(ctx context.Context, name string) (models.Template, error) {
+
+	tmpl, err := s.storage.GetTemplate(ctx, name)
+	if err != nil {
+		s.logger.LogError("service — failed to get template from DB", err, "template", name, "layer", "service.impl")
+		return models.Template{}, err
+	}
+
+	return tmpl, nil
+
+}
@@ -6,6 +6,7 @@ import (
 	"Chronos/internal/broker"
 	"Chronos/internal/cache"
 	"Chronos/internal/logger"
+	"Chronos/internal/notifier"
 	"Chronos/internal/repository"
 )
 
@@ -13,13 +14,14 @@ import (
 // It coordinates between the broker, cache, and storage layers to
 // create, retrieve, update, and cancel notifications.
 type Service struct {
-	logger  logger.Logger      // logger for structured logging
-	broker  broker.Broker      // broker layer for producing/consuming notifications
-	cache   cache.Cache        // cache layer for fast status lookups
-	storage repository.Storage // persistent storage for notifications
+	logger   logger.Logger      // logger for structured logging
+	broker   broker.Broker      // broker layer for producing/consuming notifications
+	cache    cache.Cache        // cache layer for fast status lookups
+	storage  repository.Storage // persistent storage for notifications
+	notifier notifier.Notifier  // channel registry used to validate notifications
 }
 
-// NewService creates a new Service instance with the provided logger, broker, cache, and storage.
-func NewService(logger logger.Logger, broker broker.Broker, cache cache.Cache, storage repository.Storage) *Service {
-	return &Service{logger: logger, broker: broker, cache: cache, storage: storage}
+// NewService creates a new Service instance with the provided logger, broker, cache, storage, and notifier.
+func NewService(logger logger.Logger, broker broker.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) *Service {
+	return &Service{logger: logger, broker: broker, cache: cache, storage: storage, notifier: notifier}
 }
@@ -3,9 +3,13 @@ package impl
 import (
 	mockBroker "Chronos/internal/broker/mocks"
 	mockCache "Chronos/internal/cache/mocks"
+	"Chronos/internal/config"
 	"Chronos/internal/errs"
 	mockLogger "Chronos/internal/logger/mocks"
 	"Chronos/internal/models"
+	"Chronos/internal/notifier"
+	"Chronos/internal/notifier/channel"
+	mockNotifier "Chronos/internal/notifier/mocks"
 	mockStorage "Chronos/internal/repository/mocks"
 	"context"
 	"errors"
@@ -15,9 +19,21 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wb-go/wbf/helpers"
 	"go.uber.org/mock/gomock"
 )
 
+func newTestNotifier(t *testing.T) notifier.Notifier {
+	email, err := channel.NewEmail(config.Notifier{})
+	require.NoError(t, err)
+	n, err := notifier.NewNotifier(config.Notifier{},
+		email,
+		channel.NewTelegram(config.Notifier{}),
+		channel.NewStdout())
+	require.NoError(t, err)
+	return n
+}
+
 func TestService_CancelNotification(t *testing.T) {
 
 	ctx := context.Background()
@@ -132,9 +148,10 @@ func TestService_CreateNotification(t *testing.T) {
 	mockBroker := mockBroker.NewMockBroker(controller)
 
 	svc := &Service{
-		logger:  mockLogger,
-		storage: mockStorage,
-		broker:  mockBroker,
+		logger:   mockLogger,
+		storage:  mockStorage,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
 	}
 
 	notification := models.Notification{
@@ -162,48 +179,57 @@ func TestService_CreateNotification(t *testing.T) {
 		require.EqualError(t, err, "db down")
 	})
 
-	t.Run("broker produce fails and within recovery window, storage.DeleteNotification succeeds", func(t *testing.T) {
+	t.Run("urgent notification is left to the outbox relay", func(t *testing.T) {
 		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).Return(nil)
-		mockBroker.EXPECT().Produce(gomock.Any()).Return(errors.New("broker down"))
-		mockLogger.EXPECT().LogError("service — failed to produce notification", gomock.Any(), "layer", "service.impl")
-		mockStorage.EXPECT().DeleteNotification(ctx, gomock.Any()).Return(nil)
 
 		id, err := svc.CreateNotification(ctx, notification)
-		require.Empty(t, id)
-		require.ErrorIs(t, err, errs.ErrUrgentDeliveryFailed)
+		require.NotEmpty(t, id)
+		require.NoError(t, err)
 	})
 
-	t.Run("broker produce fails and DeleteNotification fails", func(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
 		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).Return(nil)
-		mockBroker.EXPECT().Produce(gomock.Any()).Return(errors.New("broker down"))
-		mockLogger.EXPECT().LogError("service — failed to produce notification", gomock.Any(), "layer", "service.impl")
-		mockStorage.EXPECT().DeleteNotification(ctx, gomock.Any()).Return(errors.New("db delete fail"))
-		mockLogger.EXPECT().LogError("service — failed to delete notification from db", gomock.Any(), "layer", "service.impl")
 
 		id, err := svc.CreateNotification(ctx, notification)
-		require.Empty(t, id)
-		require.ErrorIs(t, err, errs.ErrUrgentDeliveryFailed)
+		require.NotEmpty(t, id)
+		require.NoError(t, err)
 	})
 
-	t.Run("broker produce fails but SendAt beyond recovery window", func(t *testing.T) {
-		longFuture := notification
-		longFuture.SendAt = time.Now().Add(2 * time.Hour)
+	t.Run("invalid time zone", func(t *testing.T) {
+		invalid := notification
+		invalid.TimeZone = "Mars/Olympus"
+		_, err := svc.CreateNotification(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrInvalidTimeZone)
+	})
 
-		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).Return(nil)
-		mockBroker.EXPECT().Produce(gomock.Any()).Return(errors.New("broker down"))
-		mockLogger.EXPECT().LogError("service — failed to produce notification", gomock.Any(), "layer", "service.impl")
+	t.Run("send_at_local is rendered in the caller's time zone", func(t *testing.T) {
+		zoned := notification
+		zoned.SendAt = time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		zoned.TimeZone = "Asia/Tokyo"
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		require.NoError(t, err)
 
-		id, err := svc.CreateNotification(ctx, longFuture)
-		require.NotEmpty(t, id)
+		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n models.Notification) error {
+			assert.Equal(t, time.UTC, n.SendAt.Location())
+			assert.Equal(t, zoned.SendAt.In(tokyo).Format("2006-01-02 15:04:05"), n.SendAtLocal)
+			assert.Equal(t, "Asia/Tokyo", n.TimeZone)
+			return nil
+		})
+
+		_, err = svc.CreateNotification(ctx, zoned)
 		require.NoError(t, err)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).Return(nil)
-		mockBroker.EXPECT().Produce(gomock.Any()).Return(nil)
+	t.Run("send_at_local keeps the caller's offset without time zone", func(t *testing.T) {
+		offset := notification
+		offset.SendAt = time.Now().Add(2 * time.Hour).In(time.FixedZone("", 5*60*60))
 
-		id, err := svc.CreateNotification(ctx, notification)
-		require.NotEmpty(t, id)
+		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n models.Notification) error {
+			assert.Equal(t, offset.SendAt.Format("2006-01-02 15:04:05"), n.SendAtLocal)
+			return nil
+		})
+
+		_, err := svc.CreateNotification(ctx, offset)
 		require.NoError(t, err)
 	})
 }
@@ -289,17 +315,20 @@ func TestNewService(t *testing.T) {
 	mockBroker := mockBroker.NewMockBroker(controller)
 	mockCache := mockCache.NewMockCache(controller)
 	mockStorage := mockStorage.NewMockStorage(controller)
+	mockNotifier := mockNotifier.NewMockNotifier(controller)
 
-	svc := NewService(mockLogger, mockBroker, mockCache, mockStorage)
+	svc := NewService(mockLogger, mockBroker, mockCache, mockStorage, mockNotifier)
 
 	require.NotNil(t, svc)
 	require.Equal(t, mockLogger, svc.logger)
 	require.Equal(t, mockBroker, svc.broker)
 	require.Equal(t, mockCache, svc.cache)
 	require.Equal(t, mockStorage, svc.storage)
+	require.Equal(t, mockNotifier, svc.notifier)
 }
 
 func TestValidateCreate(t *testing.T) {
+	testNotifier := newTestNotifier(t)
 	now := time.Now().UTC()
 	validEmail := "qweqwe@eqweq.com"
 	validNotification := models.Notification{
@@ -311,111 +340,155 @@ func TestValidateCreate(t *testing.T) {
 	}
 
 	t.Run("valid notification", func(t *testing.T) {
-		err := validateCreate(&validNotification)
+		err := validateCreate(&validNotification, testNotifier)
 		require.NoError(t, err)
 	})
 
 	t.Run("missing channel", func(t *testing.T) {
 		n := validNotification
 		n.Channel = ""
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrMissingChannel)
 	})
 
 	t.Run("unsupported channel", func(t *testing.T) {
 		n := validNotification
 		n.Channel = "fax"
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrUnsupportedChannel)
 	})
 
 	t.Run("message too long", func(t *testing.T) {
 		n := validNotification
 		n.Message = strings.Repeat("a", models.MaxMessageLength+1)
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrMessageTooLong)
 	})
 
 	t.Run("missing sendAt", func(t *testing.T) {
 		n := validNotification
 		n.SendAt = time.Time{}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrMissingSendAt)
 	})
 
 	t.Run("sendAt in past", func(t *testing.T) {
 		n := validNotification
 		n.SendAt = now.Add(-time.Hour)
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrSendAtInPast)
 	})
 
 	t.Run("sendAt too far in future", func(t *testing.T) {
 		n := validNotification
 		n.SendAt = now.AddDate(2, 0, 0)
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrSendAtTooFar)
 	})
 
 	t.Run("missing recipient", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrMissingSendTo)
 	})
 
 	t.Run("missing email subject", func(t *testing.T) {
 		n := validNotification
 		n.Subject = ""
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrMissingEmailSubject)
 	})
 
 	t.Run("email subject too long", func(t *testing.T) {
 		n := validNotification
 		n.Subject = strings.Repeat("a", models.MaxSubjectLength+1)
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrEmailSubjectTooLong)
 	})
 
 	t.Run("invalid recipient format", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{"invalid-email"}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrInvalidEmailFormat)
 	})
 
 	t.Run("recipient too long", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{strings.Repeat("a", models.MaxEmailLength+1) + "@test.com"}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrRecipientTooLong)
 	})
 
 	t.Run("empty recipient string", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{""}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrInvalidEmailFormat)
 	})
 
 	t.Run("recipient missing domain dot", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{"user@invalid"}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrInvalidEmailFormat)
 	})
 
 	t.Run("recipient missing @ symbol", func(t *testing.T) {
 		n := validNotification
 		n.SendTo = []string{"invalid.com"}
-		err := validateCreate(&n)
+		err := validateCreate(&n, testNotifier)
 		require.ErrorIs(t, err, errs.ErrInvalidEmailFormat)
 	})
 
+	t.Run("channel name is case-insensitive", func(t *testing.T) {
+		n := validNotification
+		n.Channel = "EMAIL"
+		err := validateCreate(&n, testNotifier)
+		require.NoError(t, err)
+		require.Equal(t, models.Email, n.Channel)
+	})
+
+	t.Run("channel not registered", func(t *testing.T) {
+		n := validNotification
+		n.Channel = models.Telegram
+		email, err := channel.NewEmail(config.Notifier{})
+		require.NoError(t, err)
+		onlyEmail, err := notifier.NewNotifier(config.Notifier{}, email)
+		require.NoError(t, err)
+		err = validateCreate(&n, onlyEmail)
+		require.ErrorIs(t, err, errs.ErrUnsupportedChannel)
+	})
+
+	t.Run("telegram requires chats without a default receiver", func(t *testing.T) {
+		n := validNotification
+		n.Channel = models.Telegram
+		n.SendTo = nil
+		err := validateCreate(&n, testNotifier)
+		require.ErrorIs(t, err, errs.ErrMissingSendTo)
+	})
+
+	t.Run("telegram invalid chat", func(t *testing.T) {
+		n := validNotification
+		n.Channel = models.Telegram
+		n.SendTo = []string{"@no"}
+		err := validateCreate(&n, testNotifier)
+		require.ErrorIs(t, err, errs.ErrInvalidTelegramChat)
+	})
+
+	t.Run("stdout does not require recipients", func(t *testing.T) {
+		n := validNotification
+		n.Channel = models.Stdout
+		n.SendTo = nil
+		n.Subject = ""
+		err := validateCreate(&n, testNotifier)
+		require.NoError(t, err)
+	})
+
 	t.Run("empty message replaced with invisible char", func(t *testing.T) {
 		msg := ""
-		err := validateMessage(&msg)
+		err := validateMessage(&msg, models.MaxMessageLength)
 		require.NoError(t, err)
 		require.Equal(t, "ㅤ", msg)
 	})
@@ -455,3 +528,658 @@ func TestService_GetAllStatuses(t *testing.T) {
 	})
 
 }
+
+func TestService_GetRecipients(t *testing.T) {
+
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+
+	svc := &Service{logger: mockLogger, storage: mockStorage}
+
+	t.Run("storage returns recipients", func(t *testing.T) {
+		expected := []models.Recipient{
+			{Address: "neo@matrix.com", Kind: models.RecipientTo, Status: models.StatusSent, Attempts: 1},
+			{Address: "smith@matrix.com", Kind: models.RecipientCC, Status: models.StatusFailed, Attempts: 1, LastError: "550"},
+		}
+
+		mockStorage.EXPECT().GetRecipients(ctx, "id").Return(expected, nil)
+
+		result, err := svc.GetRecipients(ctx, "id")
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("storage returns error", func(t *testing.T) {
+		mockStorage.EXPECT().GetRecipients(ctx, "id").Return(nil, errors.New("DB down"))
+		mockLogger.EXPECT().LogError("service — failed to get notification recipients from DB", gomock.Any(), "notificationID", "id", "layer", "service.impl")
+
+		_, err := svc.GetRecipients(ctx, "id")
+		require.Error(t, err)
+	})
+
+}
+
+func TestService_CreateNotification_Template(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{
+		logger:   mockLogger,
+		storage:  mockStorage,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
+	}
+
+	tmpl := models.Template{Name: "welcome", Variants: map[string]models.TemplateVariant{
+		models.DefaultVariant: {Message: "Hi {{.name}}"},
+		models.Email:          {Subject: "Welcome, {{.name}}", Message: "Hi {{.name}}, welcome aboard"},
+	}}
+
+	notification := models.Notification{
+		Channel:  "EMAIL",
+		Template: "welcome",
+		Params:   map[string]any{"name": "Neo"},
+		SendTo:   []string{"neo@matrix.com"},
+		SendAt:   time.Now().Add(time.Minute),
+	}
+
+	t.Run("template with message", func(t *testing.T) {
+		invalid := notification
+		invalid.Message = "hello"
+		_, err := svc.CreateNotification(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrTemplateWithMessage)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		mockStorage.EXPECT().GetTemplate(ctx, "welcome").Return(models.Template{}, errs.ErrTemplateNotFound)
+		_, err := svc.CreateNotification(ctx, notification)
+		require.ErrorIs(t, err, errs.ErrUnknownTemplate)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		invalid := notification
+		invalid.Params = nil
+		mockStorage.EXPECT().GetTemplate(ctx, "welcome").Return(tmpl, nil)
+		_, err := svc.CreateNotification(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrTemplateRender)
+	})
+
+	t.Run("rendered message is validated", func(t *testing.T) {
+		long := notification
+		long.Params = map[string]any{"name": strings.Repeat("a", models.MaxMessageLength)}
+		mockStorage.EXPECT().GetTemplate(ctx, "welcome").Return(tmpl, nil)
+		_, err := svc.CreateNotification(ctx, long)
+		require.ErrorIs(t, err, errs.ErrMessageTooLong)
+	})
+
+	t.Run("success stores template instead of message", func(t *testing.T) {
+		mockStorage.EXPECT().GetTemplate(ctx, "welcome").Return(tmpl, nil)
+		mockStorage.EXPECT().CreateNotification(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n models.Notification) error {
+			assert.Equal(t, models.Email, n.Channel)
+			assert.Equal(t, "welcome", n.Template)
+			assert.Equal(t, map[string]any{"name": "Neo"}, n.Params)
+			assert.Empty(t, n.Message)
+			assert.Empty(t, n.Subject)
+			return nil
+		})
+
+		id, err := svc.CreateNotification(ctx, notification)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+	})
+
+}
+
+func TestService_CreateTemplate(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+
+	svc := &Service{logger: mockLogger, storage: mockStorage, notifier: newTestNotifier(t)}
+
+	t.Run("unknown channel variant", func(t *testing.T) {
+		err := svc.CreateTemplate(ctx, models.Template{Name: "t", Variants: map[string]models.TemplateVariant{
+			"pigeon": {Message: "coo"},
+		}})
+		require.ErrorIs(t, err, errs.ErrInvalidTemplate)
+	})
+
+	t.Run("variant keys are normalized", func(t *testing.T) {
+		mockStorage.EXPECT().CreateTemplate(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tmpl models.Template) error {
+			assert.Contains(t, tmpl.Variants, models.Telegram)
+			assert.False(t, tmpl.CreatedAt.IsZero())
+			return nil
+		})
+		err := svc.CreateTemplate(ctx, models.Template{Name: "t", Variants: map[string]models.TemplateVariant{
+			"Telegram": {Message: "hi"},
+		}})
+		require.NoError(t, err)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockStorage.EXPECT().CreateTemplate(ctx, gomock.Any()).Return(errs.ErrTemplateExists)
+		mockLogger.EXPECT().LogError("service — failed to create template", gomock.Any(), "template", "t", "layer", "service.impl")
+		err := svc.CreateTemplate(ctx, models.Template{Name: "t", Variants: map[string]models.TemplateVariant{
+			models.DefaultVariant: {Message: "hi"},
+		}})
+		require.ErrorIs(t, err, errs.ErrTemplateExists)
+	})
+
+}
+
+func TestService_CreateSchedule(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{
+		logger:   mockLogger,
+		storage:  mockStorage,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
+	}
+
+	schedule := models.Schedule{
+		Cron:     "0 9 * * *",
+		TimeZone: "Europe/Moscow",
+		Notification: models.Notification{
+			Channel: "Telegram",
+			Message: "standup",
+			SendTo:  []string{"@matrix"},
+		},
+	}
+
+	t.Run("invalid recurrence", func(t *testing.T) {
+		invalid := schedule
+		invalid.Cron = "every morning"
+		_, err := svc.CreateSchedule(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrInvalidRecurrence)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		invalid := schedule
+		end := time.Now().Add(-time.Hour)
+		invalid.EndAt = &end
+		_, err := svc.CreateSchedule(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrInvalidScheduleBounds)
+	})
+
+	t.Run("no occurrences", func(t *testing.T) {
+		invalid := schedule
+		invalid.Cron = "0 0 30 2 *"
+		_, err := svc.CreateSchedule(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrNoOccurrences)
+	})
+
+	t.Run("invalid notification", func(t *testing.T) {
+		invalid := schedule
+		invalid.Notification.SendTo = []string{"not a chat"}
+		_, err := svc.CreateSchedule(ctx, invalid)
+		require.ErrorIs(t, err, errs.ErrInvalidTelegramChat)
+	})
+
+	t.Run("success stores the first occurrence", func(t *testing.T) {
+		mockStorage.EXPECT().CreateSchedule(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s models.Schedule, first models.Notification) error {
+				assert.Equal(t, models.ScheduleActive, s.Status)
+				assert.Equal(t, 1, s.Occurrences)
+				assert.Equal(t, first.ID, s.CurrentID)
+				assert.Equal(t, s.ID, first.ScheduleID)
+				assert.Equal(t, models.Telegram, first.Channel)
+				assert.Equal(t, 9, first.SendAt.In(time.FixedZone("MSK", 3*60*60)).Hour())
+				assert.True(t, first.SendAt.After(time.Now()))
+				return nil
+			})
+
+		id, err := svc.CreateSchedule(ctx, schedule)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+	})
+
+}
+
+func TestService_CancelSchedule(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockCache := mockCache.NewMockCache(controller)
+
+	svc := &Service{logger: mockLogger, storage: mockStorage, cache: mockCache}
+
+	t.Run("pending occurrence is canceled", func(t *testing.T) {
+		mockStorage.EXPECT().CancelSchedule(ctx, "schedule").Return("occurrence", nil)
+		mockCache.EXPECT().SetStatus(ctx, "occurrence", models.StatusCanceled).Return(nil)
+		require.NoError(t, svc.CancelSchedule(ctx, "schedule"))
+	})
+
+	t.Run("occurrence already processed", func(t *testing.T) {
+		mockStorage.EXPECT().CancelSchedule(ctx, "schedule").Return("", nil)
+		require.NoError(t, svc.CancelSchedule(ctx, "schedule"))
+	})
+
+	t.Run("not active", func(t *testing.T) {
+		mockStorage.EXPECT().CancelSchedule(ctx, "schedule").Return("", errs.ErrScheduleNotActive)
+		mockLogger.EXPECT().LogError("service — failed to cancel schedule in DB", gomock.Any(), "scheduleID", "schedule", "layer", "service.impl")
+		require.ErrorIs(t, svc.CancelSchedule(ctx, "schedule"), errs.ErrScheduleNotActive)
+	})
+
+}
+
+func TestService_RescheduleNotification(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockCache := mockCache.NewMockCache(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{
+		logger:   mockLogger,
+		storage:  mockStorage,
+		cache:    mockCache,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
+	}
+
+	sendAt := time.Now().Add(2 * time.Hour).UTC()
+	stored := models.Notification{
+		ID:       "aboba123",
+		Channel:  models.Telegram,
+		Message:  "old",
+		SendTo:   []string{"@matrix"},
+		Status:   models.StatusLate,
+		SendAt:   time.Now().Add(-time.Minute),
+		Revision: 1,
+	}
+
+	t.Run("send_at in past", func(t *testing.T) {
+		err := svc.RescheduleNotification(ctx, stored.ID, time.Now().Add(-time.Hour), nil)
+		require.ErrorIs(t, err, errs.ErrSendAtInPast)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(models.Notification{}, errs.ErrNotificationNotFound)
+		err := svc.RescheduleNotification(ctx, stored.ID, sendAt, nil)
+		require.ErrorIs(t, err, errs.ErrNotificationNotFound)
+	})
+
+	t.Run("already sent", func(t *testing.T) {
+		sent := stored
+		sent.Status = models.StatusSent
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(sent, nil)
+		err := svc.RescheduleNotification(ctx, stored.ID, sendAt, nil)
+		require.ErrorIs(t, err, errs.ErrCannotReschedule)
+	})
+
+	t.Run("message of templated notification", func(t *testing.T) {
+		templated := stored
+		templated.Message = ""
+		templated.Template = "welcome"
+		message := "new"
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(templated, nil)
+		err := svc.RescheduleNotification(ctx, stored.ID, sendAt, &message)
+		require.ErrorIs(t, err, errs.ErrTemplateWithMessage)
+	})
+
+	t.Run("message too long", func(t *testing.T) {
+		message := strings.Repeat("a", 5000)
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(stored, nil)
+		err := svc.RescheduleNotification(ctx, stored.ID, sendAt, &message)
+		require.ErrorIs(t, err, errs.ErrMessageTooLong)
+	})
+
+	t.Run("concurrently processed", func(t *testing.T) {
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(stored, nil)
+		mockStorage.EXPECT().RescheduleNotification(ctx, gomock.Any()).Return(errs.ErrCannotReschedule)
+		err := svc.RescheduleNotification(ctx, stored.ID, sendAt, nil)
+		require.ErrorIs(t, err, errs.ErrCannotReschedule)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := "new"
+		expiring := stored
+		expiresAt := stored.SendAt.Add(15 * time.Minute)
+		expiring.ExpiresAt = &expiresAt
+		expiring.Attempts = 2
+		mockStorage.EXPECT().GetNotification(ctx, stored.ID).Return(expiring, nil)
+		mockStorage.EXPECT().RescheduleNotification(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, n models.Notification) error {
+				assert.Equal(t, sendAt, n.SendAt)
+				require.NotNil(t, n.ExpiresAt)
+				assert.True(t, n.ExpiresAt.Equal(sendAt.Add(15*time.Minute)))
+				assert.Zero(t, n.Attempts)
+				assert.Equal(t, "new", n.Message)
+				assert.Equal(t, models.StatusPending, n.Status)
+				assert.Equal(t, 2, n.Revision)
+				return nil
+			})
+		mockCache.EXPECT().SetStatus(ctx, stored.ID, models.StatusPending).Return(nil)
+
+		require.NoError(t, svc.RescheduleNotification(ctx, stored.ID, sendAt, &message))
+	})
+
+}
+
+func TestService_CreateNotificationOnce(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{
+		logger:   mockLogger,
+		storage:  mockStorage,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
+	}
+
+	notification := models.Notification{
+		Channel: models.Stdout,
+		Message: "hello",
+		SendAt:  time.Now().Add(2 * time.Hour),
+	}
+	key := models.IdempotencyKey{Key: "order-42", RequestHash: "hash"}
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := svc.CreateNotificationOnce(ctx, notification, models.IdempotencyKey{Key: "bad\nkey"})
+		require.ErrorIs(t, err, errs.ErrInvalidIdempotencyKey)
+		_, err = svc.CreateNotificationOnce(ctx, notification, models.IdempotencyKey{Key: strings.Repeat("k", 256)})
+		require.ErrorIs(t, err, errs.ErrInvalidIdempotencyKey)
+	})
+
+	t.Run("repeated request returns the original ID without validation", func(t *testing.T) {
+		past := notification
+		past.SendAt = time.Now().Add(-time.Hour)
+		mockStorage.EXPECT().GetIdempotencyKey(ctx, key.Key).Return(
+			models.IdempotencyKey{Key: key.Key, RequestHash: "hash", NotificationID: "original"}, nil)
+
+		id, err := svc.CreateNotificationOnce(ctx, past, key)
+		require.NoError(t, err)
+		require.Equal(t, "original", id)
+	})
+
+	t.Run("different request with the same key", func(t *testing.T) {
+		mockStorage.EXPECT().GetIdempotencyKey(ctx, key.Key).Return(
+			models.IdempotencyKey{Key: key.Key, RequestHash: "other", NotificationID: "original"}, nil)
+
+		_, err := svc.CreateNotificationOnce(ctx, notification, key)
+		require.ErrorIs(t, err, errs.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("concurrent request bound the key first", func(t *testing.T) {
+		mockStorage.EXPECT().GetIdempotencyKey(ctx, key.Key).Return(models.IdempotencyKey{}, errs.ErrUnknownIdempotencyKey)
+		mockStorage.EXPECT().CreateNotificationOnce(ctx, gomock.Any(), key).Return(
+			models.IdempotencyKey{Key: key.Key, RequestHash: "hash", NotificationID: "concurrent"}, nil)
+
+		id, err := svc.CreateNotificationOnce(ctx, notification, key)
+		require.NoError(t, err)
+		require.Equal(t, "concurrent", id)
+	})
+
+	t.Run("new key creates the notification", func(t *testing.T) {
+		mockStorage.EXPECT().GetIdempotencyKey(ctx, key.Key).Return(models.IdempotencyKey{}, errs.ErrUnknownIdempotencyKey)
+		mockStorage.EXPECT().CreateNotificationOnce(ctx, gomock.Any(), key).DoAndReturn(
+			func(_ context.Context, n models.Notification, k models.IdempotencyKey) (models.IdempotencyKey, error) {
+				k.NotificationID = n.ID
+				return k, nil
+			})
+
+		id, err := svc.CreateNotificationOnce(ctx, notification, key)
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+	})
+
+}
+
+func TestService_CreateBatch(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{
+		logger:   mockLogger,
+		storage:  mockStorage,
+		broker:   mockBroker,
+		notifier: newTestNotifier(t),
+	}
+
+	valid := models.Notification{Channel: models.Stdout, Message: "hello", SendAt: time.Now().Add(2 * time.Hour)}
+	urgent := models.Notification{Channel: models.Stdout, Message: "now", SendAt: time.Now().Add(time.Minute)}
+	invalid := models.Notification{Channel: "pigeon", Message: "coo", SendAt: time.Now().Add(time.Hour)}
+
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := svc.CreateBatch(ctx, nil)
+		require.ErrorIs(t, err, errs.ErrInvalidBatchSize)
+		_, err = svc.CreateBatch(ctx, make([]models.Notification, models.MaxBatchSize+1))
+		require.ErrorIs(t, err, errs.ErrInvalidBatchSize)
+	})
+
+	t.Run("only invalid notifications", func(t *testing.T) {
+		results, err := svc.CreateBatch(ctx, []models.Notification{invalid})
+		require.NoError(t, err)
+		require.ErrorIs(t, results[0].Err, errs.ErrUnsupportedChannel)
+	})
+
+	t.Run("storage failure fails the batch", func(t *testing.T) {
+		mockStorage.EXPECT().CreateNotifications(ctx, gomock.Len(1)).Return(errors.New("db down"))
+		mockLogger.EXPECT().LogError("service — failed to create notifications", gomock.Any(), "count", 1, "layer", "service.impl")
+
+		_, err := svc.CreateBatch(ctx, []models.Notification{valid, invalid})
+		require.EqualError(t, err, "db down")
+	})
+
+	t.Run("per-item results", func(t *testing.T) {
+		mockStorage.EXPECT().CreateNotifications(ctx, gomock.Len(2)).Return(nil)
+
+		results, err := svc.CreateBatch(ctx, []models.Notification{valid, invalid, urgent})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.NotEmpty(t, results[0].ID)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, errs.ErrUnsupportedChannel)
+		assert.NotEmpty(t, results[2].ID)
+		assert.NoError(t, results[2].Err)
+	})
+
+}
+
+func TestValidateTags(t *testing.T) {
+
+	tooMany := make([]string, models.MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag"
+	}
+
+	assert.NoError(t, validateTags(nil))
+	assert.NoError(t, validateTags([]string{"order:123", "campaign/спринт"}))
+	assert.ErrorIs(t, validateTags([]string{""}), errs.ErrInvalidTag)
+	assert.ErrorIs(t, validateTags([]string{"order 123"}), errs.ErrInvalidTag)
+	assert.ErrorIs(t, validateTags([]string{strings.Repeat("t", models.MaxTagLength+1)}), errs.ErrInvalidTag)
+	assert.ErrorIs(t, validateTags(tooMany), errs.ErrInvalidTag)
+
+}
+
+func TestService_CancelByTag(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockCache := mockCache.NewMockCache(controller)
+
+	svc := &Service{logger: mockLogger, storage: mockStorage, cache: mockCache}
+
+	t.Run("invalid tag", func(t *testing.T) {
+		_, err := svc.CancelByTag(ctx, "")
+		require.ErrorIs(t, err, errs.ErrInvalidTag)
+	})
+
+	t.Run("canceled notifications are updated in cache", func(t *testing.T) {
+		mockStorage.EXPECT().CancelByTag(ctx, "order:123").Return([]string{"a", "b"}, nil)
+		mockCache.EXPECT().SetStatus(ctx, "a", models.StatusCanceled).Return(nil)
+		mockCache.EXPECT().SetStatus(ctx, "b", models.StatusCanceled).Return(errors.New("cache down"))
+		mockLogger.EXPECT().LogError("service — failed to set notification status in cache", gomock.Any(), "layer", "service.impl")
+
+		canceled, err := svc.CancelByTag(ctx, "order:123")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, canceled)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockStorage.EXPECT().CancelByTag(ctx, "order:123").Return(nil, errors.New("db down"))
+		mockLogger.EXPECT().LogError("service — failed to cancel notifications by tag", gomock.Any(), "tag", "order:123", "layer", "service.impl")
+
+		_, err := svc.CancelByTag(ctx, "order:123")
+		require.Error(t, err)
+	})
+
+}
+
+func TestValidatePriority(t *testing.T) {
+
+	for input, want := range map[string]string{
+		"":     models.PriorityNormal,
+		"high": models.PriorityHigh,
+		"BULK": models.PriorityBulk,
+	} {
+		priority := input
+		require.NoError(t, validatePriority(&priority))
+		assert.Equal(t, want, priority)
+	}
+
+	priority := "urgent"
+	assert.ErrorIs(t, validatePriority(&priority), errs.ErrInvalidPriority)
+
+}
+
+func TestValidateExpiry(t *testing.T) {
+
+	sendAt := time.Now().Add(time.Hour)
+	before := sendAt.Add(-time.Minute)
+	after := sendAt.Add(time.Minute)
+
+	assert.NoError(t, validateExpiry(&models.Notification{SendAt: sendAt}))
+	assert.NoError(t, validateExpiry(&models.Notification{SendAt: sendAt, ExpiresAt: &after}))
+	assert.ErrorIs(t, validateExpiry(&models.Notification{SendAt: sendAt, ExpiresAt: &before}), errs.ErrInvalidExpiry)
+	assert.ErrorIs(t, validateExpiry(&models.Notification{SendAt: sendAt, ExpiresAt: &sendAt}), errs.ErrInvalidExpiry)
+
+	maxDelay := "90m"
+	require.NoError(t, validateMaxDelay(&maxDelay))
+	assert.Equal(t, "1h30m0s", maxDelay)
+
+	for _, invalid := range []string{"soon", "0s", "-5m"} {
+		assert.ErrorIs(t, validateMaxDelay(&invalid), errs.ErrInvalidExpiry)
+	}
+
+}
+
+func TestService_ReplayDeadLetters(t *testing.T) {
+
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockLogger := mockLogger.NewMockLogger(controller)
+	mockStorage := mockStorage.NewMockStorage(controller)
+	mockCache := mockCache.NewMockCache(controller)
+	mockBroker := mockBroker.NewMockBroker(controller)
+
+	svc := &Service{logger: mockLogger, storage: mockStorage, cache: mockCache, broker: mockBroker}
+
+	deadLetterID := helpers.CreateUUID()
+	replayedAt := time.Now()
+	sendAt := time.Now().Add(-time.Hour).UTC()
+	expiresAt := sendAt.Add(15 * time.Minute)
+
+	failed := models.Notification{
+		ID:        "aboba123",
+		Status:    models.StatusFailed,
+		SendAt:    sendAt,
+		ExpiresAt: &expiresAt,
+		Revision:  1,
+		Attempts:  5,
+	}
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		_, err := svc.ReplayDeadLetters(ctx, nil)
+		require.ErrorIs(t, err, errs.ErrInvalidBatchSize)
+	})
+
+	t.Run("per dead letter errors", func(t *testing.T) {
+		replayed := helpers.CreateUUID()
+		sent := helpers.CreateUUID()
+
+		mockStorage.EXPECT().GetDeadLetter(ctx, replayed).Return(models.DeadLetter{ID: replayed, NotificationID: failed.ID, ReplayedAt: &replayedAt}, nil)
+		mockStorage.EXPECT().GetDeadLetter(ctx, sent).Return(models.DeadLetter{ID: sent, NotificationID: failed.ID}, nil)
+		mockStorage.EXPECT().GetNotification(ctx, failed.ID).Return(models.Notification{ID: failed.ID, Status: models.StatusSent}, nil)
+
+		results, err := svc.ReplayDeadLetters(ctx, []string{"not-a-uuid", replayed, sent})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.ErrorIs(t, results[0].Err, errs.ErrInvalidDeadLetterID)
+		assert.ErrorIs(t, results[1].Err, errs.ErrCannotReplay)
+		assert.ErrorIs(t, results[2].Err, errs.ErrCannotReplay)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockStorage.EXPECT().GetDeadLetter(ctx, deadLetterID).Return(models.DeadLetter{ID: deadLetterID, NotificationID: failed.ID}, nil)
+		mockStorage.EXPECT().GetNotification(ctx, failed.ID).Return(failed, nil)
+		mockStorage.EXPECT().ReplayDeadLetter(ctx, deadLetterID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, n models.Notification) error {
+				assert.Equal(t, models.StatusPending, n.Status)
+				assert.Equal(t, 2, n.Revision)
+				assert.Zero(t, n.Attempts)
+				assert.WithinDuration(t, time.Now(), n.SendAt, time.Second)
+				require.NotNil(t, n.ExpiresAt)
+				assert.Equal(t, 15*time.Minute, n.ExpiresAt.Sub(n.SendAt))
+				return nil
+			})
+		mockCache.EXPECT().SetStatus(ctx, failed.ID, models.StatusPending).Return(nil)
+
+		results, err := svc.ReplayDeadLetters(ctx, []string{deadLetterID})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, deadLetterID, results[0].ID)
+	})
+
+}
@@ -0,0 +1,23 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// ListByTag returns every notification carrying the given tag.
+func (s *Service) ListByTag(ctx context.Context, tag string) ([]models.Notification, error) {
+
+	if err := validateTags([]string{tag}); err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.storage.ListByTag(ctx, tag)
+	if err != nil {
+		s.logger.LogError("service — failed to list notifications by tag", err, "tag", tag, "layer", "service.impl")
+		return nil, err
+	}
+
+	return notifications, nil
+
+}
@@ -0,0 +1,24 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// ListDeadLetters returns the dead letters that have not been replayed yet, oldest first.
+// Message bodies are left out of the list; GetDeadLetter returns them.
+func (s *Service) ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error) {
+
+	deadLetters, err := s.storage.ListDeadLetters(ctx)
+	if err != nil {
+		s.logger.LogError("service — failed to list dead letters", err, "layer", "service.impl")
+		return nil, err
+	}
+
+	for i := range deadLetters {
+		deadLetters[i].Body = ""
+	}
+
+	return deadLetters, nil
+
+}
@@ -0,0 +1,19 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+)
+
+// ListTemplates returns all templates.
+func (s *Service) ListTemplates(ctx context.Context) ([]models.Template, error) {
+
+	templates, err := s.storage.ListTemplates(ctx)
+	if err != nil {
+		s.logger.LogError("service — failed to list templates from DB", err, "layer", "service.impl")
+		return nil, err
+	}
+
+	return templates, nil
+
+}
@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wb-go/wbf/helpers"
+)
+
+// ReplayDeadLetters replays the notifications of the given dead letters and returns the outcome
+// of every dead letter, in order. Only errors concerning the request as a whole are returned as an error.
+func (s *Service) ReplayDeadLetters(ctx context.Context, deadLetterIDs []string) ([]models.BatchResult, error) {
+
+	if len(deadLetterIDs) == 0 || len(deadLetterIDs) > models.MaxBatchSize {
+		return nil, errs.ErrInvalidBatchSize
+	}
+
+	results := make([]models.BatchResult, len(deadLetterIDs))
+
+	for i, id := range deadLetterIDs {
+		results[i] = models.BatchResult{ID: id, Err: s.replay(ctx, id)}
+	}
+
+	return results, nil
+
+}
+
+// replay sends the notification of a dead letter again, as soon as possible and with a fresh set of
+// delivery attempts. The notification must still be "failed to send" or "partially sent"; recipients
+// that already received it are skipped. A delivery deadline moves together with the send time, as on
+// reschedule. Like a reschedule, the change is stored together with an outbox entry, from which the
+// broker's relay enqueues the notification.
+func (s *Service) replay(ctx context.Context, deadLetterID string) error {
+
+	if err := helpers.ParseUUID(deadLetterID); err != nil {
+		return errs.ErrInvalidDeadLetterID
+	}
+
+	deadLetter, err := s.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return err
+	}
+
+	if deadLetter.ReplayedAt != nil || deadLetter.NotificationID == "" {
+		return errs.ErrCannotReplay
+	}
+
+	notification, err := s.storage.GetNotification(ctx, deadLetter.NotificationID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotificationNotFound) {
+			return errs.ErrNotificationNotFound
+		}
+		s.logger.LogError("service — failed to get notification from DB", err, "layer", "service.impl")
+		return err
+	}
+
+	if notification.Status != models.StatusFailed && notification.Status != models.StatusPartiallySent {
+		return errs.ErrCannotReplay
+	}
+
+	now := time.Now().UTC()
+
+	if notification.ExpiresAt != nil {
+		expiresAt := notification.ExpiresAt.Add(now.Sub(notification.SendAt)).UTC()
+		notification.ExpiresAt = &expiresAt
+	}
+
+	notification.SendAt = now
+	notification.SendAtLocal = localTime(now, notification.TimeZone)
+	notification.Status = models.StatusPending
+	notification.UpdatedAt = now
+	notification.Revision++
+	notification.Attempts = 0
+
+	if err := s.storage.ReplayDeadLetter(ctx, deadLetterID, notification); err != nil {
+		if !errors.Is(err, errs.ErrCannotReplay) {
+			s.logger.LogError("service — failed to replay dead letter in DB", err, "deadLetterID", deadLetterID, "layer", "service.impl")
+		}
+		return err
+	}
+
+	if err := s.cache.SetStatus(ctx, notification.ID, models.StatusPending); err != nil {
+		s.logger.LogError("service — failed to set notification status in cache", err, "layer", "service.impl")
+	}
+
+	return nil
+
+}
@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// RescheduleNotification moves a notification that is still "pending" or "running late"
+// to a new send time and optionally replaces its message, keeping its ID.
+// A delivery deadline moves together with the send time, so the allowed delay is kept.
+// The change is stored together with an outbox entry, from which the broker's relay enqueues
+// the new revision, so a broker outage delays it instead of losing it. Messages already queued
+// for the previous revision are removed by the broker or discarded by the consumer.
+func (s *Service) RescheduleNotification(ctx context.Context, notificationID string, sendAt time.Time, message *string) error {
+
+	if err := validateSendAt(sendAt); err != nil {
+		return err
+	}
+
+	notification, err := s.storage.GetNotification(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotificationNotFound) {
+			return errs.ErrNotificationNotFound
+		}
+		s.logger.LogError("service — failed to get notification from DB", err, "layer", "service.impl")
+		return err
+	}
+
+	if notification.Status != models.StatusPending && notification.Status != models.StatusLate {
+		return errs.ErrCannotReschedule
+	}
+
+	if message != nil {
+		if err := s.validateNewMessage(&notification, *message); err != nil {
+			return err
+		}
+	}
+
+	if notification.ExpiresAt != nil {
+		expiresAt := notification.ExpiresAt.Add(sendAt.Sub(notification.SendAt)).UTC()
+		notification.ExpiresAt = &expiresAt
+	}
+
+	notification.SendAt = sendAt.UTC()
+	notification.SendAtLocal = localTime(sendAt, notification.TimeZone)
+	notification.Status = models.StatusPending
+	notification.UpdatedAt = time.Now().UTC()
+	notification.Revision++
+	notification.Attempts = 0
+
+	if err := s.storage.RescheduleNotification(ctx, notification); err != nil {
+		if !errors.Is(err, errs.ErrCannotReschedule) {
+			s.logger.LogError("service — failed to reschedule notification in DB", err, "layer", "service.impl")
+		}
+		return err
+	}
+
+	if err := s.cache.SetStatus(ctx, notificationID, models.StatusPending); err != nil {
+		s.logger.LogError("service — failed to set notification status in cache", err, "layer", "service.impl")
+	}
+
+	return nil
+
+}
+
+// validateNewMessage checks a replacement message against the notification's channel
+// and sets it. Templated notifications take their message from the template, so their
+// message cannot be replaced.
+func (s *Service) validateNewMessage(notification *models.Notification, message string) error {
+
+	if notification.Template != "" {
+		return errs.ErrTemplateWithMessage
+	}
+
+	channel, err := validateChannel(notification, s.notifier)
+	if err != nil {
+		return err
+	}
+
+	if err := validateMessage(&message, channel.Capabilities().MaxMessageLength); err != nil {
+		return err
+	}
+
+	notification.Message = message
+
+	return channel.Validate(*notification)
+
+}
@@ -0,0 +1,26 @@
+package impl
+
+import (
+	"Chronos/internal/models"
+	"context"
+	"time"
+)
+
+// UpdateTemplate validates and replaces the variants of an existing template.
+// Scheduled notifications pick up the new wording, since they are rendered only when sent.
+func (s *Service) UpdateTemplate(ctx context.Context, tmpl models.Template) error {
+
+	if err := validateTemplate(&tmpl, s.notifier); err != nil {
+		return err
+	}
+
+	tmpl.UpdatedAt = time.Now().UTC()
+
+	if err := s.storage.UpdateTemplate(ctx, tmpl); err != nil {
+		s.logger.LogError("service — failed to update template", err, "template", tmpl.Name, "layer", "service.impl")
+		return err
+	}
+
+	return nil
+
+}
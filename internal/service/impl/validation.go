@@ -3,20 +3,29 @@ package impl
 import (
 	"Chronos/internal/errs"
 	"Chronos/internal/models"
-	"net/mail"
+	"Chronos/internal/notifier"
+	"Chronos/internal/recurrence"
+	"Chronos/internal/templates"
+	"fmt"
 	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 )
 
 // validateCreate performs all checks for a new notification.
-func validateCreate(notification *models.Notification) error {
+// Generic checks are driven by the channel's capabilities; channel-specific
+// checks, such as the recipient format, are delegated to the channel itself.
+func validateCreate(notification *models.Notification, notifier notifier.Notifier) error {
 
-	if err := validateChannel(notification.Channel); err != nil {
+	channel, err := validateChannel(notification, notifier)
+	if err != nil {
 		return err
 	}
 
-	if err := validateMessage(&notification.Message); err != nil {
+	capabilities := channel.Capabilities()
+
+	if err := validateMessage(&notification.Message, capabilities.MaxMessageLength); err != nil {
 		return err
 	}
 
@@ -24,38 +33,54 @@ func validateCreate(notification *models.Notification) error {
 		return err
 	}
 
-	if notification.Channel == models.Email {
-		if err := validateEmails(notification.SendTo, notification.Subject); err != nil {
-			return err
-		}
+	if err := validateTimeZone(notification.TimeZone); err != nil {
+		return err
 	}
 
-	return nil
+	if err := validateExpiry(notification); err != nil {
+		return err
+	}
 
-}
+	if err := validateRecipients(notification.SendTo, capabilities); err != nil {
+		return err
+	}
 
-// validateChannel ensures the channel is set and supported.
-func validateChannel(channel string) error {
+	if err := validateTags(notification.Tags); err != nil {
+		return err
+	}
 
-	if channel == "" {
-		return errs.ErrMissingChannel
+	if err := validatePriority(&notification.Priority); err != nil {
+		return err
 	}
 
-	ch := strings.ToLower(channel)
+	return channel.Validate(*notification)
 
-	if ch != models.Telegram && ch != models.Email && ch != models.Stdout {
-		return errs.ErrUnsupportedChannel
+}
+
+// validateChannel ensures the channel is set and registered.
+// The channel name is normalized to lower case so it matches the registry.
+func validateChannel(notification *models.Notification, notifier notifier.Notifier) (notifier.Channel, error) {
+
+	if notification.Channel == "" {
+		return nil, errs.ErrMissingChannel
 	}
 
-	return nil
+	channel, ok := notifier.Channel(notification.Channel)
+	if !ok {
+		return nil, errs.ErrUnsupportedChannel
+	}
+
+	notification.Channel = strings.ToLower(notification.Channel)
+
+	return channel, nil
 
 }
 
 // validateMessage checks the message length and ensures it's not empty.
 // If the message is empty, a placeholder is set because Telegram does not allow sending empty notifications.
-func validateMessage(message *string) error {
+func validateMessage(message *string, maxLength int) error {
 
-	if utf8.RuneCountInString(*message) > models.MaxMessageLength {
+	if maxLength > 0 && utf8.RuneCountInString(*message) > maxLength {
 		return errs.ErrMessageTooLong
 	}
 
@@ -88,43 +113,190 @@ func validateSendAt(t time.Time) error {
 
 }
 
-// validateEmails checks that recipients and subject are valid for email notifications.
-func validateEmails(recipients []string, subject string) error {
+// validateTimeZone ensures the optional time zone is a known IANA name.
+// "Local" is rejected because it refers to the server's zone rather than the caller's.
+func validateTimeZone(zone string) error {
 
-	if len(recipients) == 0 {
-		return errs.ErrMissingSendTo
+	if zone == "" {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(zone); err != nil || zone == "Local" {
+		return errs.ErrInvalidTimeZone
+	}
+
+	return nil
+
+}
+
+// validateExpiry ensures the delivery deadline, if any, is after the send time.
+// The deadline is stored in UTC like the send time.
+func validateExpiry(notification *models.Notification) error {
+
+	if notification.ExpiresAt == nil {
+		return nil
 	}
 
-	if subject == "" {
-		return errs.ErrMissingEmailSubject
+	if !notification.ExpiresAt.After(notification.SendAt) {
+		return errs.ErrInvalidExpiry
 	}
 
-	if utf8.RuneCountInString(subject) > models.MaxSubjectLength {
-		return errs.ErrEmailSubjectTooLong
+	expiresAt := notification.ExpiresAt.UTC()
+	notification.ExpiresAt = &expiresAt
+
+	return nil
+
+}
+
+// validateMaxDelay checks that a max delay is a positive duration, such as "15m",
+// and normalizes it. An empty max delay means there is no per-notification deadline.
+func validateMaxDelay(maxDelay *string) error {
+
+	if *maxDelay == "" {
+		return nil
 	}
 
-	for _, recipient := range recipients {
+	d, err := time.ParseDuration(*maxDelay)
+	if err != nil || d <= 0 {
+		return errs.ErrInvalidExpiry
+	}
 
-		if recipient == "" {
-			return errs.ErrInvalidEmailFormat
-		}
+	*maxDelay = d.String()
 
-		if len(recipient) > models.MaxEmailLength {
-			return errs.ErrRecipientTooLong
-		}
+	return nil
+
+}
+
+// validateIdempotencyKey ensures the idempotency key is 1 to MaxIdempotencyKey printable ASCII characters.
+func validateIdempotencyKey(key string) error {
+
+	if key == "" || len(key) > models.MaxIdempotencyKey {
+		return errs.ErrInvalidIdempotencyKey
+	}
 
-		addr, err := mail.ParseAddress(recipient)
-		if err != nil {
-			return errs.ErrInvalidEmailFormat
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return errs.ErrInvalidIdempotencyKey
 		}
+	}
+
+	return nil
+
+}
+
+// validateTags checks the number of tags and that every tag is 1 to MaxTagLength
+// characters without whitespace or control characters.
+func validateTags(tags []string) error {
 
-		parts := strings.Split(addr.Address, "@")
-		if len(parts) != 2 || !strings.Contains(parts[1], ".") {
-			return errs.ErrInvalidEmailFormat
+	if len(tags) > models.MaxTags {
+		return errs.ErrInvalidTag
+	}
+
+	for _, tag := range tags {
+		if tag == "" || utf8.RuneCountInString(tag) > models.MaxTagLength {
+			return errs.ErrInvalidTag
 		}
+		for _, r := range tag {
+			if unicode.IsSpace(r) || unicode.IsControl(r) {
+				return errs.ErrInvalidTag
+			}
+		}
+	}
 
+	return nil
+
+}
+
+// validatePriority ensures the priority is one of the supported ones.
+// The priority is normalized to lower case, and an empty priority defaults to PriorityNormal.
+func validatePriority(priority *string) error {
+
+	*priority = strings.ToLower(*priority)
+
+	switch *priority {
+	case "":
+		*priority = models.PriorityNormal
+	case models.PriorityHigh, models.PriorityNormal, models.PriorityBulk:
+	default:
+		return errs.ErrInvalidPriority
 	}
 
 	return nil
 
 }
+
+// validateRecipients checks the number of recipients against the channel's capabilities.
+func validateRecipients(recipients []string, capabilities notifier.Capabilities) error {
+
+	if capabilities.RequiresRecipients && len(recipients) == 0 {
+		return errs.ErrMissingSendTo
+	}
+
+	if capabilities.MaxRecipients > 0 && len(recipients) > capabilities.MaxRecipients {
+		return errs.ErrTooManyRecipients
+	}
+
+	return nil
+
+}
+
+// validateTemplate checks a template's name and syntax and ensures every variant
+// is either the default one or belongs to a registered channel.
+// Variant keys are normalized to lower case so they match channel names.
+func validateTemplate(tmpl *models.Template, notifier notifier.Notifier) error {
+
+	if err := templates.Check(*tmpl); err != nil {
+		return err
+	}
+
+	variants := make(map[string]models.TemplateVariant, len(tmpl.Variants))
+
+	for name, variant := range tmpl.Variants {
+		key := strings.ToLower(name)
+		if key != models.DefaultVariant {
+			if _, ok := notifier.Channel(key); !ok {
+				return fmt.Errorf("%w: unknown channel %s", errs.ErrInvalidTemplate, name)
+			}
+		}
+		variants[key] = variant
+	}
+
+	tmpl.Variants = variants
+
+	return nil
+
+}
+
+// validateSchedule checks the recurrence rule, time zone, bounds and max delay of a schedule and returns
+// the send time of its first occurrence. The time zone defaults to UTC and the start time
+// to now; the start time is also the DTSTART of an RRULE.
+func validateSchedule(schedule *models.Schedule, now time.Time) (time.Time, error) {
+
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = "UTC"
+	}
+
+	if schedule.StartAt.IsZero() {
+		schedule.StartAt = now
+	}
+
+	if schedule.MaxOccurrences < 0 || (schedule.EndAt != nil && !schedule.EndAt.After(schedule.StartAt)) {
+		return time.Time{}, errs.ErrInvalidScheduleBounds
+	}
+
+	if err := validateMaxDelay(&schedule.MaxDelay); err != nil {
+		return time.Time{}, err
+	}
+
+	sendAt, ok, err := recurrence.Next(*schedule, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !ok {
+		return time.Time{}, errs.ErrNoOccurrences
+	}
+
+	return sendAt, nil
+
+}
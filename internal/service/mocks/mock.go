@@ -13,6 +13,7 @@ import (
 	models "Chronos/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelByTag mocks base method.
+func (m *MockService) CancelByTag(ctx context.Context, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByTag", ctx, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelByTag indicates an expected call of CancelByTag.
+func (mr *MockServiceMockRecorder) CancelByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByTag", reflect.TypeOf((*MockService)(nil).CancelByTag), ctx, tag)
+}
+
 // CancelNotification mocks base method.
 func (m *MockService) CancelNotification(ctx context.Context, notificationID string) error {
 	m.ctrl.T.Helper()
@@ -55,6 +71,35 @@ func (mr *MockServiceMockRecorder) CancelNotification(ctx, notificationID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelNotification", reflect.TypeOf((*MockService)(nil).CancelNotification), ctx, notificationID)
 }
 
+// CancelSchedule mocks base method.
+func (m *MockService) CancelSchedule(ctx context.Context, scheduleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockServiceMockRecorder) CancelSchedule(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockService)(nil).CancelSchedule), ctx, scheduleID)
+}
+
+// CreateBatch mocks base method.
+func (m *MockService) CreateBatch(ctx context.Context, notifications []models.Notification) ([]models.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, notifications)
+	ret0, _ := ret[0].([]models.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockServiceMockRecorder) CreateBatch(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockService)(nil).CreateBatch), ctx, notifications)
+}
+
 // CreateNotification mocks base method.
 func (m *MockService) CreateNotification(ctx context.Context, notification models.Notification) (string, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +115,64 @@ func (mr *MockServiceMockRecorder) CreateNotification(ctx, notification any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockService)(nil).CreateNotification), ctx, notification)
 }
 
+// CreateNotificationOnce mocks base method.
+func (m *MockService) CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationOnce", ctx, notification, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotificationOnce indicates an expected call of CreateNotificationOnce.
+func (mr *MockServiceMockRecorder) CreateNotificationOnce(ctx, notification, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationOnce", reflect.TypeOf((*MockService)(nil).CreateNotificationOnce), ctx, notification, key)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockService) CreateSchedule(ctx context.Context, schedule models.Schedule) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockServiceMockRecorder) CreateSchedule(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockService)(nil).CreateSchedule), ctx, schedule)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockService) CreateTemplate(ctx context.Context, tmpl models.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, tmpl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockServiceMockRecorder) CreateTemplate(ctx, tmpl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockService)(nil).CreateTemplate), ctx, tmpl)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockService) DeleteTemplate(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockServiceMockRecorder) DeleteTemplate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockService)(nil).DeleteTemplate), ctx, name)
+}
+
 // GetAllStatuses mocks base method.
 func (m *MockService) GetAllStatuses(ctx context.Context) []models.Notification {
 	m.ctrl.T.Helper()
@@ -84,6 +187,51 @@ func (mr *MockServiceMockRecorder) GetAllStatuses(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStatuses", reflect.TypeOf((*MockService)(nil).GetAllStatuses), ctx)
 }
 
+// GetDeadLetter mocks base method.
+func (m *MockService) GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", ctx, deadLetterID)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockServiceMockRecorder) GetDeadLetter(ctx, deadLetterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockService)(nil).GetDeadLetter), ctx, deadLetterID)
+}
+
+// GetRecipients mocks base method.
+func (m *MockService) GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipients", ctx, notificationID)
+	ret0, _ := ret[0].([]models.Recipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipients indicates an expected call of GetRecipients.
+func (mr *MockServiceMockRecorder) GetRecipients(ctx, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipients", reflect.TypeOf((*MockService)(nil).GetRecipients), ctx, notificationID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockService) GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockServiceMockRecorder) GetSchedule(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockService)(nil).GetSchedule), ctx, scheduleID)
+}
+
 // GetStatus mocks base method.
 func (m *MockService) GetStatus(ctx context.Context, notificationID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +246,106 @@ func (mr *MockServiceMockRecorder) GetStatus(ctx, notificationID any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockService)(nil).GetStatus), ctx, notificationID)
 }
+
+// GetTemplate mocks base method.
+func (m *MockService) GetTemplate(ctx context.Context, name string) (models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, name)
+	ret0, _ := ret[0].(models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockServiceMockRecorder) GetTemplate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockService)(nil).GetTemplate), ctx, name)
+}
+
+// ListByTag mocks base method.
+func (m *MockService) ListByTag(ctx context.Context, tag string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTag", ctx, tag)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTag indicates an expected call of ListByTag.
+func (mr *MockServiceMockRecorder) ListByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTag", reflect.TypeOf((*MockService)(nil).ListByTag), ctx, tag)
+}
+
+// ListDeadLetters mocks base method.
+func (m *MockService) ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", ctx)
+	ret0, _ := ret[0].([]models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockServiceMockRecorder) ListDeadLetters(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockService)(nil).ListDeadLetters), ctx)
+}
+
+// ListTemplates mocks base method.
+func (m *MockService) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockServiceMockRecorder) ListTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockService)(nil).ListTemplates), ctx)
+}
+
+// ReplayDeadLetters mocks base method.
+func (m *MockService) ReplayDeadLetters(ctx context.Context, deadLetterIDs []string) ([]models.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetters", ctx, deadLetterIDs)
+	ret0, _ := ret[0].([]models.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDeadLetters indicates an expected call of ReplayDeadLetters.
+func (mr *MockServiceMockRecorder) ReplayDeadLetters(ctx, deadLetterIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetters", reflect.TypeOf((*MockService)(nil).ReplayDeadLetters), ctx, deadLetterIDs)
+}
+
+// RescheduleNotification mocks base method.
+func (m *MockService) RescheduleNotification(ctx context.Context, notificationID string, sendAt time.Time, message *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleNotification", ctx, notificationID, sendAt, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleNotification indicates an expected call of RescheduleNotification.
+func (mr *MockServiceMockRecorder) RescheduleNotification(ctx, notificationID, sendAt, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleNotification", reflect.TypeOf((*MockService)(nil).RescheduleNotification), ctx, notificationID, sendAt, message)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockService) UpdateTemplate(ctx context.Context, tmpl models.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, tmpl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockServiceMockRecorder) UpdateTemplate(ctx, tmpl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockService)(nil).UpdateTemplate), ctx, tmpl)
+}
@@ -7,21 +7,40 @@ import (
 	"Chronos/internal/cache"
 	"Chronos/internal/logger"
 	"Chronos/internal/models"
+	"Chronos/internal/notifier"
 	"Chronos/internal/repository"
 	"Chronos/internal/service/impl"
 	"context"
+	"time"
 )
 
 // Service defines the business logic interface for notifications.
 // It orchestrates operations across the broker, cache, and storage layers.
 type Service interface {
-	CreateNotification(ctx context.Context, notification models.Notification) (string, error) // CreateNotification creates a new notification and returns its ID.
-	GetAllStatuses(ctx context.Context) []models.Notification                                 // GetAllStatuses retrieves all notifications with their current status. Used for frontend display; not optimized.
-	GetStatus(ctx context.Context, notificationID string) (string, error)                     // GetStatus returns the current status of a specific notification by ID.
-	CancelNotification(ctx context.Context, notificationID string) error                      // CancelNotification attempts to cancel a notification by ID.
+	CreateNotification(ctx context.Context, notification models.Notification) (string, error)                                // CreateNotification creates a new notification and returns its ID.
+	CreateNotificationOnce(ctx context.Context, notification models.Notification, key models.IdempotencyKey) (string, error) // CreateNotificationOnce creates a notification unless the idempotency key was used before, returning the original ID for a repeated request.
+	CreateBatch(ctx context.Context, notifications []models.Notification) ([]models.BatchResult, error)                      // CreateBatch creates a batch of notifications and returns the ID or error of each one, in order.
+	GetAllStatuses(ctx context.Context) []models.Notification                                                                // GetAllStatuses retrieves all notifications with their current status. Used for frontend display; not optimized.
+	GetStatus(ctx context.Context, notificationID string) (string, error)                                                    // GetStatus returns the current status of a specific notification by ID.
+	GetRecipients(ctx context.Context, notificationID string) ([]models.Recipient, error)                                    // GetRecipients returns the per-recipient delivery state of a notification.
+	CancelNotification(ctx context.Context, notificationID string) error                                                     // CancelNotification attempts to cancel a notification by ID.
+	RescheduleNotification(ctx context.Context, notificationID string, sendAt time.Time, message *string) error              // RescheduleNotification moves a pending or late notification to a new send time, optionally replacing its message.
+	ListByTag(ctx context.Context, tag string) ([]models.Notification, error)                                                // ListByTag returns every notification carrying the tag.
+	CancelByTag(ctx context.Context, tag string) ([]string, error)                                                           // CancelByTag cancels the pending or late notifications carrying the tag and returns their IDs.
+	ListDeadLetters(ctx context.Context) ([]models.DeadLetter, error)                                                        // ListDeadLetters returns the dead letters that have not been replayed yet, without their bodies.
+	GetDeadLetter(ctx context.Context, deadLetterID string) (models.DeadLetter, error)                                       // GetDeadLetter returns a dead letter by ID, including its body.
+	ReplayDeadLetters(ctx context.Context, deadLetterIDs []string) ([]models.BatchResult, error)                             // ReplayDeadLetters sends the notifications of the dead letters again and returns the outcome of each one, in order.
+	CreateSchedule(ctx context.Context, schedule models.Schedule) (string, error)                                            // CreateSchedule creates a recurring schedule and returns its ID.
+	GetSchedule(ctx context.Context, scheduleID string) (models.Schedule, error)                                             // GetSchedule returns a recurring schedule by ID.
+	CancelSchedule(ctx context.Context, scheduleID string) error                                                             // CancelSchedule stops a recurring schedule and cancels its pending occurrence.
+	CreateTemplate(ctx context.Context, tmpl models.Template) error                                                          // CreateTemplate validates and stores a new message template.
+	GetTemplate(ctx context.Context, name string) (models.Template, error)                                                   // GetTemplate returns a message template by name.
+	ListTemplates(ctx context.Context) ([]models.Template, error)                                                            // ListTemplates returns all message templates.
+	UpdateTemplate(ctx context.Context, tmpl models.Template) error                                                          // UpdateTemplate validates and replaces the variants of a message template.
+	DeleteTemplate(ctx context.Context, name string) error                                                                   // DeleteTemplate deletes a message template that is not used by scheduled notifications.
 }
 
 // NewService constructs a new Service instance with all dependencies injected.
-func NewService(logger logger.Logger, broker broker.Broker, cache cache.Cache, storage repository.Storage) Service {
-	return impl.NewService(logger, broker, cache, storage)
+func NewService(logger logger.Logger, broker broker.Broker, cache cache.Cache, storage repository.Storage, notifier notifier.Notifier) Service {
+	return impl.NewService(logger, broker, cache, storage, notifier)
 }
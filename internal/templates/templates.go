@@ -0,0 +1,139 @@
+// Package templates parses and renders notification message templates.
+// Subjects and plain-text messages use text/template, HTML messages use html/template.
+// Missing parameters are treated as errors rather than rendered as "<no value>".
+package templates
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"text/template"
+)
+
+const missingKey = "missingkey=error"
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateName checks that a template name is non-empty, fits models.MaxTemplateName
+// and consists of letters, digits, dashes and underscores only.
+func ValidateName(name string) error {
+	if len(name) == 0 || len(name) > models.MaxTemplateName || !namePattern.MatchString(name) {
+		return errs.ErrInvalidTemplateName
+	}
+	return nil
+}
+
+// Check validates a template: its name, the presence of at least one variant,
+// a non-empty message in every variant, and the syntax of every subject and message.
+// Variant keys are checked against the known channels by the caller.
+func Check(tmpl models.Template) error {
+
+	if err := ValidateName(tmpl.Name); err != nil {
+		return err
+	}
+
+	if len(tmpl.Variants) == 0 {
+		return fmt.Errorf("%w: at least one variant is required", errs.ErrInvalidTemplate)
+	}
+
+	for channel, variant := range tmpl.Variants {
+
+		if variant.Message == "" {
+			return fmt.Errorf("%w: variant %s has no message", errs.ErrInvalidTemplate, channel)
+		}
+
+		if _, err := template.New("subject").Parse(variant.Subject); err != nil {
+			return fmt.Errorf("%w: variant %s subject: %v", errs.ErrInvalidTemplate, channel, err)
+		}
+
+		if _, err := template.New("message").Parse(variant.Message); err != nil {
+			return fmt.Errorf("%w: variant %s message: %v", errs.ErrInvalidTemplate, channel, err)
+		}
+
+		if _, err := htmltemplate.New("html_message").Parse(variant.HTMLMessage); err != nil {
+			return fmt.Errorf("%w: variant %s html_message: %v", errs.ErrInvalidTemplate, channel, err)
+		}
+
+	}
+
+	return nil
+
+}
+
+// Render renders the template variant for the notification's channel with the notification's params
+// and stores the result in its message fields. The subject and HTML message are only
+// overwritten if the variant defines them.
+func Render(tmpl models.Template, notification *models.Notification) error {
+
+	variant, ok := tmpl.Variant(notification.Channel)
+	if !ok {
+		return fmt.Errorf("template %s has no variant for channel %s", tmpl.Name, notification.Channel)
+	}
+
+	message, err := renderText("message", variant.Message, notification.Params)
+	if err != nil {
+		return err
+	}
+
+	var subject, htmlMessage string
+
+	if variant.Subject != "" {
+		if subject, err = renderText("subject", variant.Subject, notification.Params); err != nil {
+			return err
+		}
+	}
+
+	if variant.HTMLMessage != "" {
+		if htmlMessage, err = renderHTML(variant.HTMLMessage, notification.Params); err != nil {
+			return err
+		}
+	}
+
+	notification.Message = message
+	if subject != "" {
+		notification.Subject = subject
+	}
+	if htmlMessage != "" {
+		notification.HTMLMessage = htmlMessage
+	}
+
+	return nil
+
+}
+
+// renderText executes a text/template with the given params.
+func renderText(name string, text string, params map[string]any) (string, error) {
+
+	tmpl, err := template.New(name).Option(missingKey).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return buf.String(), nil
+
+}
+
+// renderHTML executes an html/template with the given params, escaping them for HTML.
+func renderHTML(text string, params map[string]any) (string, error) {
+
+	tmpl, err := htmltemplate.New("html_message").Option(missingKey).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html_message template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to render html_message template: %w", err)
+	}
+
+	return buf.String(), nil
+
+}
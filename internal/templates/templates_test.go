@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"Chronos/internal/errs"
+	"Chronos/internal/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var alert = models.Template{
+	Name: "disk-alert",
+	Variants: map[string]models.TemplateVariant{
+		models.DefaultVariant: {
+			Message: "Disk {{.disk}} is {{.usage}}% full",
+		},
+		models.Email: {
+			Subject:     "Disk alert: {{.disk}}",
+			Message:     "Disk {{.disk}} on {{.host}} is {{.usage}}% full",
+			HTMLMessage: "<p>Disk <b>{{.disk}}</b> is {{.usage}}% full</p>",
+		},
+	},
+}
+
+func TestRender(t *testing.T) {
+
+	t.Run("channel variant", func(t *testing.T) {
+		n := models.Notification{
+			Channel: models.Email,
+			Params:  map[string]any{"disk": "<sda>", "host": "neo", "usage": 97},
+		}
+		require.NoError(t, Render(alert, &n))
+		assert.Equal(t, "Disk alert: <sda>", n.Subject)
+		assert.Equal(t, "Disk <sda> on neo is 97% full", n.Message)
+		assert.Equal(t, "<p>Disk <b>&lt;sda&gt;</b> is 97% full</p>", n.HTMLMessage)
+	})
+
+	t.Run("default variant keeps request subject", func(t *testing.T) {
+		n := models.Notification{
+			Channel: models.Telegram,
+			Subject: "kept",
+			Params:  map[string]any{"disk": "sda", "usage": 97},
+		}
+		require.NoError(t, Render(alert, &n))
+		assert.Equal(t, "kept", n.Subject)
+		assert.Equal(t, "Disk sda is 97% full", n.Message)
+		assert.Empty(t, n.HTMLMessage)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		n := models.Notification{Channel: models.Telegram, Params: map[string]any{"disk": "sda"}}
+		err := Render(alert, &n)
+		require.Error(t, err)
+		assert.Empty(t, n.Message)
+	})
+
+	t.Run("no variant", func(t *testing.T) {
+		tmpl := models.Template{Name: "email-only", Variants: map[string]models.TemplateVariant{
+			models.Email: {Message: "hi"},
+		}}
+		n := models.Notification{Channel: models.Telegram}
+		require.Error(t, Render(tmpl, &n))
+	})
+
+}
+
+func TestCheck(t *testing.T) {
+
+	require.NoError(t, Check(alert))
+
+	tests := []struct {
+		name string
+		tmpl models.Template
+		err  error
+	}{
+		{"empty name", models.Template{Variants: alert.Variants}, errs.ErrInvalidTemplateName},
+		{"name with spaces", models.Template{Name: "disk alert", Variants: alert.Variants}, errs.ErrInvalidTemplateName},
+		{"name too long", models.Template{Name: strings.Repeat("a", models.MaxTemplateName+1), Variants: alert.Variants}, errs.ErrInvalidTemplateName},
+		{"no variants", models.Template{Name: "empty"}, errs.ErrInvalidTemplate},
+		{"empty message", models.Template{Name: "empty", Variants: map[string]models.TemplateVariant{
+			models.DefaultVariant: {Subject: "hi"}}}, errs.ErrInvalidTemplate},
+		{"bad syntax", models.Template{Name: "broken", Variants: map[string]models.TemplateVariant{
+			models.DefaultVariant: {Message: "{{.name"}}}, errs.ErrInvalidTemplate},
+		{"bad html syntax", models.Template{Name: "broken", Variants: map[string]models.TemplateVariant{
+			models.Email: {Message: "hi", HTMLMessage: "{{end}}"}}}, errs.ErrInvalidTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, Check(tt.tmpl), tt.err)
+		})
+	}
+
+}
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS Recipients DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS message_id;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS reply_to;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS html_message;
@@ -0,0 +1,5 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS html_message TEXT NOT NULL DEFAULT '';
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS reply_to     VARCHAR(254) NOT NULL DEFAULT '';
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS message_id   VARCHAR(300) NOT NULL DEFAULT '';
+
+ALTER TABLE Recipients ADD COLUMN IF NOT EXISTS kind VARCHAR(3) NOT NULL DEFAULT 'to';
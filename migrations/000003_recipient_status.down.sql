@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS Recipients DROP COLUMN IF EXISTS delivered_at;
+ALTER TABLE IF EXISTS Recipients DROP COLUMN IF EXISTS last_error;
+ALTER TABLE IF EXISTS Recipients DROP COLUMN IF EXISTS attempts;
+ALTER TABLE IF EXISTS Recipients DROP COLUMN IF EXISTS status;
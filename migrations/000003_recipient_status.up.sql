@@ -0,0 +1,4 @@
+ALTER TABLE Recipients ADD COLUMN IF NOT EXISTS status       VARCHAR(30) NOT NULL DEFAULT 'pending';
+ALTER TABLE Recipients ADD COLUMN IF NOT EXISTS attempts     INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE Recipients ADD COLUMN IF NOT EXISTS last_error   TEXT NOT NULL DEFAULT '';
+ALTER TABLE Recipients ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITH TIME ZONE;
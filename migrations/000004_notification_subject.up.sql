@@ -0,0 +1 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS subject VARCHAR(254) NOT NULL DEFAULT '';
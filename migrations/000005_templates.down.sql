@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_notifications_template_name;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS params;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS template_name;
+DROP TABLE IF EXISTS Templates;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS Templates (
+    name       VARCHAR(64) PRIMARY KEY,
+    variants   JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS template_name VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS params        JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_notifications_template_name ON Notifications(template_name) WHERE template_name <> '';
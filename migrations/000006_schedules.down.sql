@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS schedule_uuid;
+DROP TABLE IF EXISTS Schedules;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS Schedules (
+    id              INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    uuid            VARCHAR(36) NOT NULL UNIQUE,
+    cron            VARCHAR(255) NOT NULL DEFAULT '',
+    rrule           VARCHAR(255) NOT NULL DEFAULT '',
+    time_zone       VARCHAR(64) NOT NULL,
+    start_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at          TIMESTAMP WITH TIME ZONE,
+    max_occurrences INTEGER NOT NULL DEFAULT 0,
+    occurrences     INTEGER NOT NULL DEFAULT 0,
+    status          VARCHAR(30) NOT NULL DEFAULT 'active',
+    current_uuid    VARCHAR(36) NOT NULL,
+    next_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    notification    JSONB NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS schedule_uuid VARCHAR(36) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_schedules_status_updated_at ON Schedules(status, updated_at);
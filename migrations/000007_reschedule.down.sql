@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS revision;
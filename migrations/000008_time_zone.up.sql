@@ -0,0 +1 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS IdempotencyKeys (
+    key               VARCHAR(255) PRIMARY KEY,
+    request_hash      CHAR(64) NOT NULL,
+    notification_uuid VARCHAR(36) NOT NULL REFERENCES Notifications(uuid) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+    expires_at        TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON IdempotencyKeys(expires_at);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_notification_uuid ON IdempotencyKeys(notification_uuid);
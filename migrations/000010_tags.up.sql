@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS NotificationTags (
+    tag               VARCHAR(64) NOT NULL,
+    notification_uuid VARCHAR(36) NOT NULL REFERENCES Notifications(uuid) ON DELETE CASCADE,
+    PRIMARY KEY (tag, notification_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_tags_notification_uuid ON NotificationTags(notification_uuid);
@@ -0,0 +1 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT 'normal';
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS Schedules DROP COLUMN IF EXISTS max_delay;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS expires_at;
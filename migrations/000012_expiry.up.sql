@@ -0,0 +1,2 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE Schedules ADD COLUMN IF NOT EXISTS max_delay VARCHAR(32) NOT NULL DEFAULT '';
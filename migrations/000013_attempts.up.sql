@@ -0,0 +1 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS DeadLetters (
+    uuid              VARCHAR(36) PRIMARY KEY,
+    notification_uuid VARCHAR(36) NOT NULL DEFAULT '',
+    reason            TEXT NOT NULL,
+    body              TEXT NOT NULL,
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    replayed_at       TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_created_at ON DeadLetters(created_at);
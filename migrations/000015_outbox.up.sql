@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS Outbox (
+    id                INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    notification_uuid VARCHAR(36) NOT NULL REFERENCES Notifications(uuid) ON DELETE CASCADE,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    published_at      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished ON Outbox(id) WHERE published_at IS NULL;
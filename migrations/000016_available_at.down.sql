@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notifications_status_available_at;
+ALTER TABLE IF EXISTS Notifications DROP COLUMN IF EXISTS available_at;
@@ -0,0 +1,3 @@
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS available_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_status_available_at ON Notifications(status, COALESCE(available_at, send_at));
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS Outbox DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1 @@
+ALTER TABLE Outbox ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
@@ -17,8 +17,9 @@ document.addEventListener("DOMContentLoaded", () => {
   sendAtInput.value = localIso;
 
   channelSelect.addEventListener("change", () => {
+    const channel = channelSelect.value.toLowerCase();
     emailFields.style.display =
-      channelSelect.value.toLowerCase() === "email" ? "block" : "none";
+      channel === "stdout" ? "none" : "block";
   });
   channelSelect.dispatchEvent(new Event("change"));
 
@@ -145,6 +146,27 @@ document.addEventListener("DOMContentLoaded", () => {
       }
     }
 
+    if (channel === "telegram") {
+      payload.send_to = document
+        .getElementById("sendTo")
+        .value.split(",")
+        .map((s) => s.trim())
+        .filter(Boolean);
+    }
+
+    if (channel === "webhook") {
+      payload.subject = document.getElementById("subject").value;
+      payload.send_to = document
+        .getElementById("sendTo")
+        .value.split(",")
+        .map((s) => s.trim())
+        .filter(Boolean);
+      if (!payload.send_to.length) {
+        alert("Webhook URL is required");
+        return;
+      }
+    }
+
     try {
       const res = await fetch(apiBase, {
         method: "POST",